@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gookit/color"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/agenda"
+	"github.com/sho0pi/tickli/internal/completion"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/utils"
+	"github.com/spf13/cobra"
+	"io"
+	"os"
+	"time"
+)
+
+type agendaOptions struct {
+	days   int
+	output types.OutputFormat
+}
+
+func NewAgendaCommand() *cobra.Command {
+	opts := &agendaOptions{}
+	cmd := &cobra.Command{
+		Use:   "agenda [project...]",
+		Short: "List the tasks of the coming days, day by day",
+		Long: `List the open tasks due or starting on each of the coming days, across every
+open project unless projects are given by ID or name.
+
+Overdue tasks come first. Each day then lists its all-day tasks followed by
+timed ones in order. All-day tasks fall on their date in the time zone of the
+task, timed tasks are shown in local time, with the time of the task's own zone
+when it differs.
+
+A task spanning several days is listed on its start and on its due day.`,
+		Example: `  # What's on this week
+  tickli agenda
+  
+  # The next two weeks of the work projects
+  tickli agenda Work Meetings --days 14
+  
+  # The agenda as JSON, for scripts
+  tickli agenda -o json`,
+		ValidArgsFunction: completion.ProjectIDs(),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if opts.days < 1 {
+				return fmt.Errorf("days must be at least 1, got %d", opts.days)
+			}
+			switch opts.output {
+			case "", types.OutputSimple, types.OutputJSON:
+				return nil
+			default:
+				return fmt.Errorf("agenda output must be simple or json, got %s", opts.output)
+			}
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := utils.LoadClient()
+			projects, err := client.ListProjectsData(args...)
+			if err != nil {
+				return errors.Wrap(err, "failed to get projects")
+			}
+			a := agenda.Build(projects, opts.days, time.Now())
+
+			if opts.output == types.OutputJSON {
+				data, err := json.MarshalIndent(a, "", "  ")
+				if err != nil {
+					return errors.Wrap(err, "failed to marshal agenda")
+				}
+				fmt.Println(string(data))
+				return nil
+			}
+			printAgenda(os.Stdout, a)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVarP(&opts.days, "days", "d", 7, "Number of days to list, starting today")
+	cmd.Flags().VarP(&opts.output, "output", "o", "Display format: simple or json")
+	_ = cmd.RegisterFlagCompletionFunc("output", types.SimpleOrJSONCompletionFunc)
+
+	return cmd
+}
+
+func printAgenda(w io.Writer, a agenda.Agenda) {
+	if len(a.Overdue) > 0 {
+		fmt.Fprintln(w, color.Red.Sprint(color.Bold.Sprint("Overdue")))
+		for _, e := range a.Overdue {
+			printAgendaEntry(w, e, e.Time.Format("Jan 2"))
+		}
+		fmt.Fprintln(w)
+	}
+
+	for i, day := range a.Days {
+		title := day.Date.Format("Monday, Jan 2")
+		switch i {
+		case 0:
+			title = "Today · " + title
+		case 1:
+			title = "Tomorrow · " + title
+		}
+		fmt.Fprintln(w, color.Bold.Sprint(title))
+		if len(day.Entries) == 0 {
+			fmt.Fprintln(w, color.Gray.Sprint("  Nothing planned"))
+		}
+		for _, e := range day.Entries {
+			when := "all day"
+			if !e.IsAllDay {
+				when = e.Time.Format("15:04")
+			}
+			printAgendaEntry(w, e, when)
+		}
+		if i < len(a.Days)-1 {
+			fmt.Fprintln(w)
+		}
+	}
+}
+
+func printAgendaEntry(w io.Writer, e agenda.Entry, when string) {
+	when = fmt.Sprintf("%-7s", when)
+	if e.Overdue {
+		when = color.Red.Sprint(when)
+	}
+	line := fmt.Sprintf("  %s %s %s", when, e.Task.Priority.Color().Sprint("⚑"), e.Task.Title)
+	if e.Kind == agenda.KindStart {
+		line += color.Gray.Sprint(" (starts)")
+	}
+	if local, other := e.TaskTime(); other {
+		line += color.Gray.Sprintf(" (%s %s)", local.Format("15:04"), e.Task.TimeZone)
+	}
+	fmt.Fprintf(w, "%s  %s\n", line, color.Gray.Sprint(e.Project))
+}
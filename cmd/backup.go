@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/backup"
+	"github.com/sho0pi/tickli/internal/config"
+	"github.com/sho0pi/tickli/internal/utils"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+type backupOptions struct {
+	file     string
+	compress bool
+}
+
+func NewBackupCommand() *cobra.Command {
+	opts := &backupOptions{}
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Back up every project, task and the config to a JSON archive",
+		Long: `Snapshot the whole account into a versioned JSON archive: every project,
+closed ones included, with its tasks and kanban columns, and the tickli config.
+
+The archive is written to stdout unless a file is given with --write, files
+ending with .gz are gzip compressed. Restore an archive with 'tickli restore'.
+
+Only open tasks are included. Project data doesn't hold completed tasks, which
+the TickTick API only lists by completion date, as 'tickli today' does.`,
+		Example: `  # Nightly backup from cron
+  tickli backup -w ~/backups/tickli-$(date +%F).json.gz
+  
+  # Print the archive
+  tickli backup | jq '.projects[].project.name'`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := utils.LoadClient()
+			cfg, err := config.Load()
+			if err != nil {
+				return errors.Wrap(err, "failed to load config")
+			}
+
+			archive, err := backup.Create(client.WithRefresh(), *cfg)
+			if err != nil {
+				return err
+			}
+
+			if opts.file == "" || opts.file == "-" {
+				return backup.Write(os.Stdout, archive, opts.compress)
+			}
+			if err := backup.WriteFile(opts.file, archive); err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Backed up %d projects and %d tasks to %s\n",
+				len(archive.Projects), archive.TaskCount(), opts.file)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.file, "write", "w", "", "Write the archive to this file instead of stdout")
+	cmd.Flags().BoolVarP(&opts.compress, "gzip", "z", false, "Gzip compress the archive written to stdout")
+
+	return cmd
+}
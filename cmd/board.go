@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/completion"
+	"github.com/sho0pi/tickli/internal/config"
+	"github.com/sho0pi/tickli/internal/render"
+	"github.com/sho0pi/tickli/internal/tui"
+	"github.com/sho0pi/tickli/internal/utils"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+type boardOptions struct {
+	projectID   string
+	interactive bool
+	width       int
+}
+
+func NewBoardCommand() *cobra.Command {
+	opts := &boardOptions{}
+	cmd := &cobra.Command{
+		Use:   "board [project-id]",
+		Short: "Show the tasks of a project as a kanban board",
+		Long: `Lay out the tasks of a project by kanban column, side by side.
+
+Cards show the title, due date and checklist progress of each task, with a
+border in its priority colour and chips for its tags. Columns fill the width
+of the terminal and wrap to another row when there are too many to fit. Tasks
+outside any column are shown first. If no project ID is provided, the
+currently active project is shown.
+
+With --interactive, move between cards with the arrow keys and move the
+selected card to the previous or next column with H and L.`,
+		Example: `  # Show the board of the current project
+  tickli board
+  
+  # Move cards around the sprint board
+  tickli board abc123def456 -i`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completion.ProjectIDs(),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.projectID = args[0]
+				return nil
+			}
+			cfg, err := config.Load()
+			if err != nil {
+				return errors.Wrap(err, "failed to load config")
+			}
+			opts.projectID = cfg.DefaultProjectID
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := utils.LoadClient()
+			if opts.interactive {
+				return tui.RunBoard(&client, opts.projectID)
+			}
+
+			data, err := client.GetProjectWithTasks(opts.projectID)
+			if err != nil {
+				return errors.Wrap(err, "failed to get project data")
+			}
+			if data.Project.ID == "" {
+				if data.Project, err = client.GetProject(opts.projectID); err != nil {
+					return errors.Wrap(err, "failed to get project")
+				}
+			}
+
+			width := opts.width
+			if width <= 0 {
+				width = render.TerminalWidth(os.Stdout)
+			}
+			if width <= 0 {
+				width = 120
+			}
+			fmt.Println(tui.RenderBoard(data, width))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.interactive, "interactive", "i", false, "Browse the board and move cards between columns")
+	cmd.Flags().IntVarP(&opts.width, "width", "w", 0, "Width of the board, the terminal width by default")
+
+	return cmd
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/sho0pi/tickli/internal/cache"
+	"github.com/sho0pi/tickli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func NewCacheCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the local cache of projects and tasks",
+		Long: `Projects and tasks are cached on disk to speed up commands and shell
+completion. Cached data is used for --cache-ttl (5 minutes by default) and
+dropped when tickli changes it. Use --refresh to fetch everything again, or
+--offline to work from the cache without the network.`,
+	}
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "clear",
+			Short: "Remove all cached projects and tasks",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				if err := cache.New(config.CacheDir()).Clear(); err != nil {
+					return err
+				}
+				fmt.Println("Cache cleared")
+				return nil
+			},
+		},
+		&cobra.Command{
+			Use:   "path",
+			Short: "Print the cache directory",
+			Args:  cobra.NoArgs,
+			Run: func(cmd *cobra.Command, args []string) {
+				fmt.Println(config.CacheDir())
+			},
+		},
+	)
+
+	return cmd
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/gookit/color"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/agenda"
+	"github.com/sho0pi/tickli/internal/completion"
+	"github.com/sho0pi/tickli/internal/timeline"
+	"github.com/sho0pi/tickli/internal/utils"
+	"github.com/spf13/cobra"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type calOptions struct {
+	projects []string
+}
+
+func NewCalCommand() *cobra.Command {
+	opts := &calOptions{}
+	cmd := &cobra.Command{
+		Use:   "cal [month]",
+		Short: "Show a month calendar with the number of tasks per day",
+		Long: `Show a month as a calendar grid, with the number of open tasks due or
+starting on each day.
+
+Today is highlighted, and days holding overdue tasks are shown in red. The
+month defaults to the current one and may be given as a name, a number, a
+YYYY-MM date or in natural language. Every open project is counted unless
+projects are given with --project.`,
+		Example: `  # This month
+  tickli cal
+  
+  # Next month, for the work project only
+  tickli cal "next month" --project Work
+  
+  # A specific month
+  tickli cal 2026-12`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			now := time.Now()
+			month := now
+			if len(args) > 0 {
+				var err error
+				if month, err = parseMonth(args[0], now); err != nil {
+					return err
+				}
+			}
+
+			client := utils.LoadClient()
+			projects, err := client.ListProjectsData(opts.projects...)
+			if err != nil {
+				return errors.Wrap(err, "failed to get projects")
+			}
+			printMonth(os.Stdout, agenda.BuildMonth(projects, month, now), now)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&opts.projects, "project", "p", nil, "Only count the tasks of these projects, by ID or name")
+	_ = cmd.RegisterFlagCompletionFunc("project", completion.ProjectIDs())
+
+	return cmd
+}
+
+// parseMonth reads a month as YYYY-MM, a month name or number of this year, or a natural language
+// expression
+func parseMonth(expr string, now time.Time) (time.Time, error) {
+	for _, layout := range []string{"2006-01", "January 2006", "Jan 2006", "1/2006"} {
+		if t, err := time.ParseInLocation(layout, expr, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	for _, layout := range []string{"January", "Jan"} {
+		if t, err := time.ParseInLocation(layout, expr, time.Local); err == nil {
+			return time.Date(now.Year(), t.Month(), 1, 0, 0, 0, 0, time.Local), nil
+		}
+	}
+	if n, err := strconv.Atoi(expr); err == nil && n >= 1 && n <= 12 {
+		return time.Date(now.Year(), time.Month(n), 1, 0, 0, 0, 0, time.Local), nil
+	}
+	r, err := utils.ParseTimeExpression(expr)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "invalid month: %s", expr)
+	}
+	return r.Start(), nil
+}
+
+func printMonth(w io.Writer, m agenda.Month, now time.Time) {
+	const cellWidth = 8
+	title := m.First.Format("January 2006")
+	fmt.Fprintf(w, "%s%s\n", strings.Repeat(" ", (7*cellWidth-len(title))/2), color.Bold.Sprint(title))
+	for _, name := range []string{"Mo", "Tu", "We", "Th", "Fr", "Sa", "Su"} {
+		fmt.Fprint(w, color.Gray.Sprintf(" %-*s", cellWidth-1, name))
+	}
+	fmt.Fprintln(w)
+
+	// Weeks start on Monday
+	offset := (int(m.First.Weekday()) + 6) % 7
+	fmt.Fprint(w, strings.Repeat(" ", offset*cellWidth))
+	today := timeline.DaysBetween(m.First, now)
+	overdue := 0
+	for i := range m.Days() {
+		number := fmt.Sprintf("%2d", i+1)
+		count := ""
+		if m.Counts[i] > 0 {
+			count = fmt.Sprintf("(%d)", m.Counts[i])
+		}
+		padding := strings.Repeat(" ", max(cellWidth-len(number)-len(count)-2, 0))
+
+		weekday := (offset + i) % 7
+		switch {
+		case i == today:
+			number = color.Bold.Sprint(color.BgBlue.Sprint(number))
+		case weekday >= 5:
+			number = color.Gray.Sprint(number)
+		}
+		switch {
+		case m.Overdue[i] > 0:
+			count = color.Red.Sprint(count)
+			overdue += m.Overdue[i]
+		case count != "":
+			count = color.Cyan.Sprint(count)
+		}
+		fmt.Fprintf(w, " %s %s%s", number, count, padding)
+		if weekday == 6 && i < m.Days()-1 {
+			fmt.Fprintln(w)
+		}
+	}
+	fmt.Fprintln(w)
+
+	summary := fmt.Sprintf("\n%d tasks", m.Total())
+	if overdue > 0 {
+		summary += ", " + color.Red.Sprintf("%d overdue", overdue)
+	}
+	fmt.Fprintln(w, summary)
+}
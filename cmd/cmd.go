@@ -4,9 +4,14 @@ import (
 	"fmt"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"github.com/sho0pi/tickli/cmd/export"
+	"github.com/sho0pi/tickli/cmd/imports"
+	"github.com/sho0pi/tickli/cmd/notify"
 	"github.com/sho0pi/tickli/cmd/project"
 	"github.com/sho0pi/tickli/cmd/subtask"
 	"github.com/sho0pi/tickli/cmd/task"
+	"github.com/sho0pi/tickli/cmd/trash"
+	"github.com/sho0pi/tickli/internal/utils"
 	"github.com/spf13/cobra"
 	"os"
 	"time"
@@ -25,11 +30,36 @@ Complete documentation is available at https://github.com/sho0pi/tickli`,
 		NewInitCommand(),
 		NewResetCommand(),
 		NewVersionCommand(),
+		NewServeCommand(),
+		NewBackupCommand(),
+		NewRestoreCommand(),
+		NewDiffCommand(),
+		NewCacheCommand(),
+		NewSyncCommand(),
+		NewSyncMarkdownCommand(),
+		NewWatchCommand(),
+		NewUICommand(),
+		NewBoardCommand(),
+		NewTimelineCommand(),
+		NewAgendaCommand(),
+		NewCalCommand(),
+		NewTodayCommand(),
+		NewHistoryCommand(),
+		NewUndoCommand(),
 		task.NewTaskCommand(),
 		project.NewProjectCommand(),
 		subtask.NewSubtaskCommand(),
+		export.NewExportCommand(),
+		imports.NewImportCommand(),
+		notify.NewNotifyCommand(),
+		trash.NewTrashCommand(),
 	)
 
+	cmd.PersistentFlags().DurationVar(&utils.CacheOptions.TTL, "cache-ttl", utils.CacheOptions.TTL, "How long cached projects and tasks are used, 0 disables the cache")
+	cmd.PersistentFlags().BoolVar(&utils.CacheOptions.Refresh, "refresh", false, "Ignore cached projects and tasks, fetching them again")
+	cmd.PersistentFlags().BoolVar(&utils.CacheOptions.Offline, "offline", false, "Only use cached projects and tasks, without network requests")
+	cmd.MarkFlagsMutuallyExclusive("refresh", "offline")
+
 	return cmd
 }
 
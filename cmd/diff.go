@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gookit/color"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/backup"
+	"github.com/sho0pi/tickli/internal/config"
+	"github.com/sho0pi/tickli/internal/render"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/utils"
+	"github.com/spf13/cobra"
+	"io"
+	"os"
+)
+
+type diffOptions struct {
+	output types.OutputFormat
+}
+
+func NewDiffCommand() *cobra.Command {
+	opts := &diffOptions{}
+	cmd := &cobra.Command{
+		Use:   "diff <snapshot-a> [snapshot-b|live]",
+		Short: "Show what changed between two backups, or since a backup",
+		Long: `Compare two archives created by 'tickli backup', or an archive with the
+live account when the second snapshot is omitted or is "live".
+
+Projects and tasks are reported as added, removed, completed or modified,
+modified ones with the old and new value of each changed field. Snapshots only
+hold open tasks, when comparing with live data the server is asked whether
+vanished tasks were completed or deleted.`,
+		Example: `  # What changed since last week's backup
+  tickli diff ~/backups/tickli-2025-01-01.json.gz
+  
+  # Compare two backups as JSON
+  tickli diff monday.json friday.json -o json`,
+		Args: cobra.RangeArgs(1, 2),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			switch opts.output {
+			case "", types.OutputSimple, types.OutputJSON:
+				return nil
+			default:
+				return fmt.Errorf("diff output must be simple or json, got %s", opts.output)
+			}
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			before, err := backup.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			live := len(args) == 1 || args[1] == "live"
+			var after *backup.Archive
+			var client api.Client
+			if live {
+				client = utils.LoadClient()
+				cfg, err := config.Load()
+				if err != nil {
+					return errors.Wrap(err, "failed to load config")
+				}
+				if after, err = backup.Create(client.WithRefresh(), *cfg); err != nil {
+					return err
+				}
+			} else if after, err = backup.ReadFile(args[1]); err != nil {
+				return err
+			}
+
+			diff := backup.Compare(before, after)
+			if live {
+				diff.ResolveCompleted(&client)
+			}
+
+			if opts.output == types.OutputJSON {
+				data, err := json.MarshalIndent(diff, "", "  ")
+				if err != nil {
+					return errors.Wrap(err, "failed to marshal diff")
+				}
+				fmt.Println(string(data))
+				return nil
+			}
+			printDiff(os.Stdout, diff, live)
+			return nil
+		},
+	}
+
+	cmd.Flags().VarP(&opts.output, "output", "o", "Display format: simple or json")
+	_ = cmd.RegisterFlagCompletionFunc("output", types.SimpleOrJSONCompletionFunc)
+
+	return cmd
+}
+
+func printDiff(w io.Writer, diff backup.Diff, live bool) {
+	to := diff.To.Local().Format("2006-01-02 15:04")
+	if live {
+		to = "live"
+	}
+	fmt.Fprintf(w, "Changes from %s to %s\n", diff.From.Local().Format("2006-01-02 15:04"), to)
+	if len(diff.Changes) == 0 {
+		fmt.Fprintln(w, "\nNo changes")
+		return
+	}
+
+	sections := []struct{ kind, title string }{
+		{"project", "Projects"},
+		{"task", "Tasks"},
+	}
+	for _, section := range sections {
+		first := true
+		for _, c := range diff.Changes {
+			if c.Type != section.kind {
+				continue
+			}
+			if first {
+				fmt.Fprintf(w, "\n%s:\n", section.title)
+				first = false
+			}
+			name := c.Name
+			if c.Project != "" {
+				name = fmt.Sprintf("%s (%s)", c.Name, c.Project)
+			}
+			fmt.Fprintf(w, "  %s %s\n", changeSymbol(c.Kind), name)
+			for _, f := range c.Fields {
+				fmt.Fprintf(w, "      %s: %s → %s\n", f.Field,
+					color.Red.Sprint(quoteText(f.Old)), color.Green.Sprint(quoteText(f.New)))
+			}
+		}
+	}
+
+	fmt.Fprintf(w, "\n%d added, %d removed, %d completed, %d modified\n",
+		diff.Count(backup.ChangeAdded), diff.Count(backup.ChangeRemoved),
+		diff.Count(backup.ChangeCompleted), diff.Count(backup.ChangeModified))
+}
+
+func changeSymbol(kind backup.ChangeKind) string {
+	switch kind {
+	case backup.ChangeAdded:
+		return color.Green.Sprint("+")
+	case backup.ChangeRemoved:
+		return color.Red.Sprint("-")
+	case backup.ChangeCompleted:
+		return color.Green.Sprint("☑")
+	default:
+		return color.Yellow.Sprint("~")
+	}
+}
+
+func quoteText(v any) string {
+	if v == nil {
+		return "(none)"
+	}
+	text := render.Text(v)
+	if text == "" {
+		return "(none)"
+	}
+	return fmt.Sprintf("%q", text)
+}
@@ -0,0 +1,30 @@
+package export
+
+import (
+	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// NewExportCommand returns a cobra command for `export` subcommands
+func NewExportCommand() *cobra.Command {
+	var client api.Client
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export tasks to other applications",
+		Long: `Export tasks across projects into formats understood by other applications.
+
+To export a single project as a document, see 'tickli project export'.`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			client = utils.LoadClient()
+			return nil
+		},
+	}
+
+	cmd.AddCommand(
+		newICSCommand(&client),
+		newTaskwarriorCommand(&client),
+	)
+
+	return cmd
+}
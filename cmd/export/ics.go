@@ -0,0 +1,68 @@
+package export
+
+import (
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/completion"
+	"github.com/sho0pi/tickli/internal/export"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+type icsOptions struct {
+	projectIDs []string
+	name       string
+	component  export.ICSComponent
+	file       string
+}
+
+func newICSCommand(client *api.Client) *cobra.Command {
+	opts := &icsOptions{
+		component: export.ICSEvent,
+	}
+	cmd := &cobra.Command{
+		Use:   "ics",
+		Short: "Export dated tasks as an iCalendar (.ics) file",
+		Long: `Export the tasks with a start or due date as an iCalendar feed.
+
+Tasks of all open projects are exported unless projects are selected with
+--project-id. Each task becomes a VEVENT (or a VTODO with --component todo)
+whose UID is derived from the task ID, so re-imported calendars update
+existing entries. Repeat rules become RRULEs and reminders become VALARMs.`,
+		Example: `  # Export all dated tasks
+  tickli export ics > tasks.ics
+  
+  # Export two projects as VTODO entries
+  tickli export ics -P abc123def456 -P xyz789 --component todo -w deadlines.ics`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projects, err := client.ListProjectsData(opts.projectIDs...)
+			if err != nil {
+				return errors.Wrap(err, "failed to fetch tasks")
+			}
+
+			out := os.Stdout
+			if opts.file != "" {
+				f, err := os.Create(opts.file)
+				if err != nil {
+					return errors.Wrap(err, "failed to create output file")
+				}
+				defer f.Close()
+				out = f
+			}
+			return export.ICS(out, projects, export.ICSOptions{
+				Name:      opts.name,
+				Component: opts.component,
+			})
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&opts.projectIDs, "project-id", "P", nil, "Only export these projects (default: all open projects)")
+	_ = cmd.RegisterFlagCompletionFunc("project-id", completion.ProjectIDs())
+	cmd.Flags().StringVar(&opts.name, "name", "TickTick", "Calendar name shown by calendar apps")
+	cmd.Flags().Var(&opts.component, "component", "Calendar component of each task: event or todo")
+	_ = cmd.RegisterFlagCompletionFunc("component", export.ICSComponentCompletionFunc)
+	cmd.Flags().StringVarP(&opts.file, "write", "w", "", "Write the calendar to this file instead of stdout")
+
+	return cmd
+}
@@ -0,0 +1,61 @@
+package export
+
+import (
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/completion"
+	"github.com/sho0pi/tickli/internal/export"
+	"github.com/spf13/cobra"
+	"os"
+	"time"
+)
+
+type taskwarriorOptions struct {
+	projectIDs []string
+	file       string
+}
+
+func newTaskwarriorCommand(client *api.Client) *cobra.Command {
+	opts := &taskwarriorOptions{}
+	cmd := &cobra.Command{
+		Use:   "taskwarrior",
+		Short: "Export tasks in the Taskwarrior JSON format",
+		Long: `Export tasks as a JSON array understood by 'task import'.
+
+Projects map to Taskwarrior projects, tags to tags, priorities to H, M and L,
+start dates to scheduled and due dates to due. Each line of the task content
+becomes an annotation. UUIDs are derived from the task IDs, so importing a
+newer export into Taskwarrior updates the tasks instead of duplicating them.
+
+To migrate the other way, see 'tickli import taskwarrior'.`,
+		Example: `  # Move every open task to Taskwarrior
+  tickli export taskwarrior | task import
+  
+  # Export a single project to a file
+  tickli export taskwarrior -P abc123def456 -w work.json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projects, err := client.ListProjectsData(opts.projectIDs...)
+			if err != nil {
+				return errors.Wrap(err, "failed to fetch tasks")
+			}
+
+			out := os.Stdout
+			if opts.file != "" {
+				f, err := os.Create(opts.file)
+				if err != nil {
+					return errors.Wrap(err, "failed to create output file")
+				}
+				defer f.Close()
+				out = f
+			}
+			return export.Taskwarrior(out, projects, time.Now())
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&opts.projectIDs, "project-id", "P", nil, "Only export these projects (default: all open projects)")
+	_ = cmd.RegisterFlagCompletionFunc("project-id", completion.ProjectIDs())
+	cmd.Flags().StringVarP(&opts.file, "write", "w", "", "Write the tasks to this file instead of stdout")
+
+	return cmd
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gookit/color"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/config"
+	"github.com/sho0pi/tickli/internal/history"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/spf13/cobra"
+	"slices"
+)
+
+type historyOptions struct {
+	limit  int
+	output types.OutputFormat
+}
+
+func NewHistoryCommand() *cobra.Command {
+	opts := &historyOptions{}
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List the changes tickli made, newest first",
+		Long: fmt.Sprintf(`List the changes made through tickli, newest first, with their entry number.
+
+Every create, update, completion, deletion and move of a task or project that
+reached TickTick is recorded with the state it changed, so 'tickli undo' can
+revert it. Changes queued while offline are recorded once synced. The last %d
+changes are kept.`, history.MaxEntries),
+		Example: `  # The last changes
+  tickli history
+  
+  # Every recorded change as JSON, with the saved tasks and projects
+  tickli history -n 0 -o json`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			switch opts.output {
+			case "", types.OutputSimple, types.OutputJSON:
+				return nil
+			default:
+				return fmt.Errorf("history output must be simple or json, got %s", opts.output)
+			}
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			entries, err := history.Open(config.HistoryPath()).Entries()
+			if err != nil {
+				return errors.Wrap(err, "failed to read history")
+			}
+			slices.Reverse(entries)
+			if opts.limit > 0 && len(entries) > opts.limit {
+				entries = entries[:opts.limit]
+			}
+
+			if opts.output == types.OutputJSON {
+				data, err := json.MarshalIndent(entries, "", "  ")
+				if err != nil {
+					return errors.Wrap(err, "failed to marshal history")
+				}
+				fmt.Println(string(data))
+				return nil
+			}
+			if len(entries) == 0 {
+				fmt.Println("No changes recorded")
+				return nil
+			}
+			for _, e := range entries {
+				printHistoryEntry(e)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVarP(&opts.limit, "limit", "n", 20, "Number of changes to list, 0 for all")
+	cmd.Flags().VarP(&opts.output, "output", "o", "Display format: simple or json")
+	_ = cmd.RegisterFlagCompletionFunc("output", types.SimpleOrJSONCompletionFunc)
+
+	return cmd
+}
+
+func printHistoryEntry(e history.Entry) {
+	prefix := fmt.Sprintf("#%-4d %s", e.ID, e.Time.Local().Format("2006-01-02 15:04"))
+	if e.Undone {
+		fmt.Println(color.Gray.Sprintf("%s %s (undone)", prefix, e.Summary()))
+		return
+	}
+	fmt.Printf("%s %s\n", color.Gray.Sprint(prefix), e.Summary())
+}
@@ -0,0 +1,155 @@
+package imports
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/completion"
+	"github.com/sho0pi/tickli/internal/config"
+	"github.com/sho0pi/tickli/internal/importer"
+	"github.com/sho0pi/tickli/internal/utils"
+	"github.com/spf13/cobra"
+	"io"
+	"os"
+)
+
+type importOptions struct {
+	from           importer.Format
+	mapping        map[string]string
+	projectID      string
+	createProjects bool
+	dryRun         bool
+}
+
+// NewImportCommand returns a cobra command importing tasks from files
+func NewImportCommand() *cobra.Command {
+	var client api.Client
+	opts := &importOptions{
+		from: importer.FormatCSV,
+	}
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import tasks from CSV, JSON or todo.txt files",
+		Long: `Create tasks from the rows of a CSV, JSON or todo.txt file.
+
+Columns are matched to task fields by name (title, content, project, priority,
+tags, start, due, allDay, timeZone, status and id), use --map to map other
+column names. Projects are resolved by ID or name, and can be created with
+--create-projects. Rows without a project go to the current project.
+
+Imports are idempotent: every created task is marked with the row ID (or a
+hash of its title and dates), and rows already imported are skipped, whether
+their task is still open or was completed since.
+
+To restore a backup exported from the TickTick web settings, see
+'tickli import ticktick-backup'.`,
+		Example: `  # Preview a CSV import
+  tickli import tasks.csv --dry-run
+  
+  # Import a spreadsheet export with custom column names
+  tickli import backlog.csv --map title=Summary --map due=Deadline --create-projects
+  
+  # Import rows piped from another tool
+  some-tool --csv | tickli import -
+  
+  # Import a todo.txt file
+  tickli import --from todotxt ~/todo.txt
+  
+  # Copy tasks between accounts
+  tickli task list -o json > tasks.json && tickli import --from json tasks.json`,
+		Args: cobra.ExactArgs(1),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			client = utils.LoadClient()
+			if opts.projectID == "" {
+				cfg, err := config.Load()
+				if err != nil {
+					return errors.Wrap(err, "failed to load config")
+				}
+				opts.projectID = cfg.DefaultProjectID
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f, err := openInput(args[0])
+			if err != nil {
+				return errors.Wrap(err, "failed to open import file")
+			}
+			defer f.Close()
+
+			records, err := importer.Read(f, opts.from, opts.mapping)
+			if err != nil {
+				return errors.Wrap(err, "failed to read import file")
+			}
+			return runImport(&client, records, opts)
+		},
+	}
+
+	cmd.Flags().VarP(&opts.from, "from", "f", "Format of the file: csv, json or todotxt")
+	_ = cmd.RegisterFlagCompletionFunc("from", importer.FormatCompletionFunc)
+	cmd.Flags().StringToStringVarP(&opts.mapping, "map", "m", nil, "Map a task field to a column (e.g., 'title=Summary')")
+	cmd.PersistentFlags().StringVarP(&opts.projectID, "project-id", "P", "", "Project of rows without one (default: current project)")
+	_ = cmd.RegisterFlagCompletionFunc("project-id", completion.ProjectIDs())
+	cmd.PersistentFlags().BoolVar(&opts.createProjects, "create-projects", false, "Create projects that don't exist")
+	cmd.PersistentFlags().BoolVarP(&opts.dryRun, "dry-run", "n", false, "Show what would be imported without creating anything")
+
+	cmd.AddCommand(
+		newTickTickBackupCommand(&client, opts),
+		newTaskwarriorCommand(&client, opts),
+	)
+
+	return cmd
+}
+
+func runImport(client *api.Client, records []importer.Record, opts *importOptions) error {
+	im := importer.New(client, importer.Options{
+		DefaultProject: opts.projectID,
+		CreateProjects: opts.createProjects,
+		DryRun:         opts.dryRun,
+	})
+	summary, err := im.Import(records, printResult(opts.dryRun))
+	if err != nil {
+		return err
+	}
+	return printSummary(summary, opts.dryRun)
+}
+
+func printResult(dryRun bool) func(importer.Result) {
+	return func(r importer.Result) {
+		title := r.Record.Task.Title
+		switch r.Action {
+		case importer.ActionCreated:
+			if dryRun {
+				fmt.Printf("+ would create %q in %s\n", title, r.Project.Name)
+			} else {
+				fmt.Printf("+ created %q in %s (%s)\n", title, r.Project.Name, r.TaskID)
+			}
+			if r.Err != nil {
+				fmt.Printf("  ! %s\n", r.Err)
+			}
+		case importer.ActionSkipped:
+			fmt.Printf("= skipped %q, already imported in %s\n", title, r.Project.Name)
+		case importer.ActionFailed:
+			fmt.Printf("! failed %q (line %d): %s\n", title, r.Record.Line, r.Err)
+		}
+	}
+}
+
+func printSummary(summary importer.Summary, dryRun bool) error {
+	verb := "created"
+	if dryRun {
+		verb = "to create"
+	}
+	fmt.Printf("\n%d %s, %d skipped, %d failed\n", summary.Created, verb, summary.Skipped, summary.Failed)
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d tasks failed to import", summary.Failed)
+	}
+	return nil
+}
+
+// openInput opens the file to import, "-" reading from stdin
+func openInput(path string) (io.ReadCloser, error) {
+	if path == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+	return os.Open(path)
+}
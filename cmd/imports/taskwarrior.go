@@ -0,0 +1,48 @@
+package imports
+
+import (
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/importer"
+	"github.com/spf13/cobra"
+)
+
+func newTaskwarriorCommand(client *api.Client, opts *importOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "taskwarrior <file>",
+		Short: "Import tasks from a Taskwarrior JSON export",
+		Long: `Create tasks from the output of 'task export'.
+
+Taskwarrior projects map to projects, tags to tags, H, M and L priorities to
+high, medium and low, scheduled dates to start dates and due dates to due.
+Annotations are joined into the task content. Completed tasks are completed
+again, deleted tasks and recurring templates are left out.
+
+Projects are matched by name, use --create-projects to create missing ones.
+Importing the same export twice skips the tasks created by the first run, and
+tasks exported by 'tickli export taskwarrior' skip the TickTick task they came
+from while it still exists.`,
+		Example: `  # Migrate everything from Taskwarrior
+  task export | tickli import taskwarrior - --create-projects
+  
+  # Preview the pending tasks of a project
+  task project:work status:pending export > work.json
+  tickli import taskwarrior work.json --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f, err := openInput(args[0])
+			if err != nil {
+				return errors.Wrap(err, "failed to open taskwarrior export")
+			}
+			defer f.Close()
+
+			records, err := importer.ReadTaskwarrior(f)
+			if err != nil {
+				return err
+			}
+			return runImport(client, records, opts)
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,51 @@
+package imports
+
+import (
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/importer"
+	"github.com/spf13/cobra"
+)
+
+func newTickTickBackupCommand(client *api.Client, opts *importOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ticktick-backup <file>",
+		Short: "Restore a TickTick backup CSV",
+		Long: `Restore the backup CSV exported from the TickTick web settings
+(Settings → Account → Backup) into the current account.
+
+Lists are matched to projects by name and created when missing, unless
+--create-projects=false is given. Checklist tasks keep their items, subtasks
+become checklist items of their parent, and completed tasks are completed again.
+Tasks are placed in the kanban column of the same name when the project has one.
+
+Folders and kanban columns cannot be created through the TickTick API, create
+them in the app first to keep the original layout. Restoring the same backup
+twice skips the tasks created by the first run.`,
+		Example: `  # Preview what a backup would create
+  tickli import ticktick-backup TickTick-backup.csv --dry-run
+  
+  # Clone a template workspace into a new account
+  tickli import ticktick-backup team-template.csv`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("create-projects") {
+				opts.createProjects = true
+			}
+
+			f, err := openInput(args[0])
+			if err != nil {
+				return errors.Wrap(err, "failed to open backup file")
+			}
+			defer f.Close()
+
+			records, err := importer.ReadTickTickBackup(f)
+			if err != nil {
+				return errors.Wrap(err, "failed to read backup file")
+			}
+			return runImport(client, records, opts)
+		},
+	}
+
+	return cmd
+}
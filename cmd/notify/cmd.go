@@ -0,0 +1,32 @@
+package notify
+
+import (
+	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// NewNotifyCommand returns a cobra command for `notify` subcommands
+func NewNotifyCommand() *cobra.Command {
+	var client api.Client
+	cmd := &cobra.Command{
+		Use:   "notify",
+		Short: "Fire task reminders on this machine",
+		Long: `Fire the reminders of your tasks as local notifications.
+
+TickTick only delivers reminders through its own apps, 'tickli notify daemon'
+reads the reminders of open tasks and fires them through desktop
+notifications, the terminal bell or your own command.`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			client = utils.LoadClient()
+			return nil
+		},
+	}
+
+	cmd.AddCommand(
+		newDaemonCommand(&client),
+		newUpcomingCommand(&client),
+	)
+
+	return cmd
+}
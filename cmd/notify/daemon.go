@@ -0,0 +1,113 @@
+package notify
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/completion"
+	"github.com/sho0pi/tickli/internal/config"
+	"github.com/sho0pi/tickli/internal/notify"
+	"github.com/spf13/cobra"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+)
+
+type daemonOptions struct {
+	notifiers []string
+	command   string
+	interval  time.Duration
+	catchUp   time.Duration
+}
+
+func newDaemonCommand(client *api.Client) *cobra.Command {
+	opts := &daemonOptions{
+		notifiers: []string{string(notify.KindDesktop)},
+	}
+	cmd := &cobra.Command{
+		Use:   "daemon [project...]",
+		Short: "Keep running, firing task reminders when they are due",
+		Long: `Fire the reminders of open tasks when they are due, until interrupted.
+
+Reminders are relative to the start of a task, or its due date when it has no
+start, all-day tasks counting from the start of their day. Every open project
+is checked unless projects are given by ID or name. Tasks are fetched past the
+cache every --interval, so reminders set moments ago are seen.
+
+Notifiers:
+  desktop  notify-send (libnotify), high priority tasks are critical
+  bell     the terminal bell and a line on stdout
+  exec     the --exec shell command, the alert JSON on its stdin and the
+           TICKLI_TASK_ID, TICKLI_TASK_TITLE, TICKLI_PROJECT_ID,
+           TICKLI_PROJECT, TICKLI_TASK_PRIORITY, TICKLI_REMINDER,
+           TICKLI_ALERT_AT and TICKLI_TASK_TIME environment variables
+
+Fired alerts are remembered, restarting the daemon doesn't repeat them.
+Reminders missed by up to --catch-up, while the daemon or the machine was
+down, fire on start. A reminder no notifier could deliver is retried at the
+next check while within --catch-up.`,
+		Example: `  # Desktop notifications for every project
+  tickli notify daemon
+  
+  # Ring the terminal and log alerts
+  tickli notify daemon --notifier bell,exec --exec 'cat >> ~/reminders.ndjson'
+  
+  # Only the work project, without catching up on missed reminders
+  tickli notify daemon Work --catch-up 0`,
+		ValidArgsFunction: completion.ProjectIDs(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var notifiers []notify.Notifier
+			for _, name := range opts.notifiers {
+				kind, err := notify.ParseKind(name)
+				if err != nil {
+					return err
+				}
+				switch kind {
+				case notify.KindDesktop:
+					notifiers = append(notifiers, notify.Desktop{})
+				case notify.KindBell:
+					notifiers = append(notifiers, notify.Bell{W: os.Stdout})
+				case notify.KindExec:
+					if opts.command == "" {
+						return errors.New("the exec notifier needs a command, set --exec")
+					}
+					notifiers = append(notifiers, notify.Exec{Command: opts.command, Stdout: os.Stderr, Stderr: os.Stderr})
+				}
+			}
+
+			projectIDs, err := client.ResolveProjectIDs(args...)
+			if err != nil {
+				return errors.Wrap(err, "failed to resolve projects")
+			}
+			fired, err := notify.LoadFired(filepath.Join(config.StateDir(), "notify", "fired.json"))
+			if err != nil {
+				return err
+			}
+			d := &notify.Daemon{
+				Client:     client,
+				ProjectIDs: projectIDs,
+				Notifiers:  notifiers,
+				Fired:      fired,
+				CatchUp:    opts.catchUp,
+				OnError: func(err error) {
+					log.Error().Err(err).Msg("Reminder failed")
+				},
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+			log.Info().Strs("notifiers", opts.notifiers).Msg("Firing reminders, press Ctrl+C to stop")
+			return d.Run(ctx, opts.interval)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&opts.notifiers, "notifier", "n", opts.notifiers, "Notifiers to fire reminders through: desktop, bell, exec")
+	cmd.Flags().StringVarP(&opts.command, "exec", "x", "", "Shell command run by the exec notifier for each reminder")
+	cmd.Flags().DurationVarP(&opts.interval, "interval", "i", time.Minute, "How often to check for due reminders")
+	cmd.Flags().DurationVar(&opts.catchUp, "catch-up", 15*time.Minute, "Fire reminders missed by up to this long")
+	_ = cmd.RegisterFlagCompletionFunc("notifier", notify.KindCompletionFunc)
+
+	return cmd
+}
@@ -0,0 +1,50 @@
+package notify
+
+import (
+	"fmt"
+	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/completion"
+	"github.com/sho0pi/tickli/internal/notify"
+	"github.com/spf13/cobra"
+	"time"
+)
+
+type upcomingOptions struct {
+	within time.Duration
+}
+
+func newUpcomingCommand(client *api.Client) *cobra.Command {
+	opts := &upcomingOptions{}
+	cmd := &cobra.Command{
+		Use:   "upcoming [project...]",
+		Short: "List the reminders the daemon will fire",
+		Long: `List the reminders of open tasks due within the given duration, soonest
+first, as 'tickli notify daemon' would fire them.`,
+		Example: `  # Reminders of the next day
+  tickli notify upcoming
+  
+  # Reminders of the week in the work project
+  tickli notify upcoming Work --within 168h`,
+		ValidArgsFunction: completion.ProjectIDs(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projects, err := client.ListProjectsData(args...)
+			if err != nil {
+				return err
+			}
+			now := time.Now()
+			alerts := notify.Alerts(projects, now, now.Add(opts.within))
+			if len(alerts) == 0 {
+				fmt.Println("No upcoming reminders")
+				return nil
+			}
+			for _, a := range alerts {
+				fmt.Printf("%s  %s  %s\n", a.At.Local().Format("Mon Jan 2 15:04"), a.Summary(), a.Project)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().DurationVarP(&opts.within, "within", "w", 24*time.Hour, "How far ahead to list reminders")
+
+	return cmd
+}
@@ -26,6 +26,8 @@ func NewProjectCommand() *cobra.Command {
 		newUseProjectCmd(&client),
 		newShowCommand(&client),
 		newDeleteCommand(&client),
+		newExportCommand(&client),
+		newColumnsCommand(&client),
 	)
 
 	return cmd
@@ -0,0 +1,90 @@
+package project
+
+import (
+	"fmt"
+	"github.com/mattn/go-runewidth"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/completion"
+	"github.com/sho0pi/tickli/internal/config"
+	"github.com/sho0pi/tickli/internal/export"
+	"github.com/spf13/cobra"
+)
+
+func newColumnsCommand(client *api.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "columns",
+		Aliases: []string{"column", "col"},
+		Short:   "Work with the kanban columns of a project",
+		Long: `Work with the kanban columns of a project.
+
+The TickTick Open API only exposes columns for reading: they can be listed and
+tasks can be moved between them with 'tickli task move --column', but
+creating, renaming, reordering and deleting columns has to be done in the
+TickTick app.`,
+	}
+
+	cmd.AddCommand(
+		newColumnsListCommand(client),
+	)
+
+	return cmd
+}
+
+func newColumnsListCommand(client *api.Client) *cobra.Command {
+	var projectID string
+	cmd := &cobra.Command{
+		Use:     "list [project-id]",
+		Aliases: []string{"ls"},
+		Short:   "List the kanban columns of a project",
+		Long: `List the kanban columns of a project in board order, with the number of
+open tasks in each. Tasks outside any column are counted first. If no project
+ID is provided, the currently active project is used.`,
+		Example: `  # Columns of the current project
+  tickli project columns list
+  
+  # Columns of another project
+  tickli project columns list abc123def456`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completion.ProjectIDs(),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				projectID = args[0]
+				return nil
+			}
+			cfg, err := config.Load()
+			if err != nil {
+				return errors.Wrap(err, "failed to load config")
+			}
+			projectID = cfg.DefaultProjectID
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := client.GetProjectWithTasks(projectID)
+			if err != nil {
+				return errors.Wrap(err, "failed to get project data")
+			}
+			if len(data.Columns) == 0 {
+				fmt.Println("The project has no columns, switch it to the kanban view in TickTick to add some")
+				return nil
+			}
+
+			groups := export.GroupByColumn(data)
+			names := make([]string, len(groups))
+			width := 0
+			for i, g := range groups {
+				names[i] = g.Name
+				if g.ColumnID == "" {
+					names[i] = "(no column)"
+				}
+				width = max(width, runewidth.StringWidth(names[i]))
+			}
+			for i, g := range groups {
+				fmt.Printf("%s  %3d tasks  %s\n", runewidth.FillRight(names[i], width), len(g.Tasks), g.ColumnID)
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
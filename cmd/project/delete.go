@@ -20,8 +20,11 @@ func newDeleteCommand(client *api.Client) *cobra.Command {
 		Short: "Delete an existing project",
 		Long: `Permanently delete a project by its ID.
     
-This operation cannot be undone. By default, you will be asked to confirm
-the deletion unless the --force flag is used.`,
+A copy of the project with its tasks and columns is kept in the local trash.
+The project and its open tasks can be recreated with 'tickli undo' or
+'tickli trash restore', under new IDs and without their kanban columns. By
+default, you will be asked to confirm the deletion unless the --force flag is
+used.`,
 		Example: `  # Delete with confirmation prompt
   tickli project delete abc123def456
   
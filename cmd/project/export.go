@@ -0,0 +1,73 @@
+package project
+
+import (
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/completion"
+	"github.com/sho0pi/tickli/internal/config"
+	"github.com/sho0pi/tickli/internal/export"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+type exportOptions struct {
+	projectID string
+	format    export.Format
+}
+
+func newExportCommand(client *api.Client) *cobra.Command {
+	opts := &exportOptions{
+		format: export.FormatMarkdown,
+	}
+	cmd := &cobra.Command{
+		Use:   "export [project-id]",
+		Short: "Export a project as a Markdown, todo.txt or Org document",
+		Long: `Export the tasks of a project as a document ready to paste into docs and wikis.
+
+Tasks are grouped by their kanban column, with checkboxes for their status,
+nested checklist items, and due dates, priorities and tags written in the
+conventions of each format. If no project ID is provided, the currently
+active project is exported.`,
+		Example: `  # Export the current project as Markdown
+  tickli project export
+  
+  # Export a project as todo.txt
+  tickli project export abc123def456 --format todotxt >> todo.txt
+  
+  # Export a project as an Org file
+  tickli project export abc123def456 -f org > sprint.org`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completion.ProjectIDs(),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.projectID = args[0]
+			} else {
+				cfg, err := config.Load()
+				if err != nil {
+					return errors.Wrap(err, "failed to load config")
+				}
+				opts.projectID = cfg.DefaultProjectID
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectData, err := client.GetProjectWithTasks(opts.projectID)
+			if err != nil {
+				return errors.Wrap(err, "failed to get project data")
+			}
+			if projectData.Project.ID == "" {
+				project, err := client.GetProject(opts.projectID)
+				if err != nil {
+					return errors.Wrap(err, "failed to get project")
+				}
+				projectData.Project = project
+			}
+			return export.Project(os.Stdout, projectData, opts.format)
+		},
+	}
+
+	cmd.Flags().VarP(&opts.format, "format", "f", "Document format: markdown, todotxt or org")
+	_ = cmd.RegisterFlagCompletionFunc("format", export.FormatCompletionFunc)
+
+	return cmd
+}
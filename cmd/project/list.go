@@ -4,14 +4,17 @@ import (
 	"fmt"
 	"github.com/pkg/errors"
 	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/render"
 	"github.com/sho0pi/tickli/internal/types"
 	"github.com/sho0pi/tickli/internal/utils"
 	"github.com/spf13/cobra"
+	"os"
 	"strings"
 )
 
 type listOptions struct {
 	filter string
+	output render.Options
 }
 
 func filterProjectByName(projects []types.Project, name string) ([]types.Project, error) {
@@ -37,12 +40,22 @@ func newListCommand(client *api.Client) *cobra.Command {
 		Long: `Display all available projects and allow selection of one.
 
 This command shows all projects matching the optional filter criteria,
-then displays a fuzzy-search selector to choose a project.`,
+then displays a fuzzy-search selector to choose a project. When an output
+format is given, all matching projects are printed instead.`,
 		Example: `  # List all projects
   tickli project list
   
   # Filter projects by name
-  tickli project list -f "work"`,
+  tickli project list -f "work"
+  
+  # Print all projects as a table
+  tickli project list -o table
+  
+  # Export all projects as CSV
+  tickli project list -o csv > projects.csv
+  
+  # Print project names with their colors
+  tickli project list -o 'template={{projectColor .Color .Name}}'`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			projects, err := client.ListProjects()
 			if err != nil {
@@ -54,6 +67,10 @@ then displays a fuzzy-search selector to choose a project.`,
 				return err
 			}
 
+			if opts.output.IsSet() {
+				return render.Projects(os.Stdout, projects, &opts.output)
+			}
+
 			project, err := utils.FuzzySelectProject(projects, "")
 			if err != nil {
 				return errors.Wrap(err, "failed to select project")
@@ -65,6 +82,7 @@ then displays a fuzzy-search selector to choose a project.`,
 	}
 
 	cmd.Flags().StringVarP(&opts.filter, "filter", "f", "", "Only show projects with names containing the provided text")
+	render.AddFlags(cmd, &opts.output)
 
 	return cmd
 }
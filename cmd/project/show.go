@@ -1,27 +1,24 @@
 package project
 
 import (
-	"encoding/json"
 	"fmt"
 	"github.com/pkg/errors"
 	"github.com/sho0pi/tickli/internal/api"
 	"github.com/sho0pi/tickli/internal/completion"
 	"github.com/sho0pi/tickli/internal/config"
-	"github.com/sho0pi/tickli/internal/types"
-	"github.com/sho0pi/tickli/internal/utils"
+	"github.com/sho0pi/tickli/internal/render"
 	"github.com/spf13/cobra"
+	"os"
 )
 
 type showOptions struct {
 	projectID string
 	withTasks bool
-	output    types.OutputFormat
+	output    render.Options
 }
 
 func newShowCommand(client *api.Client) *cobra.Command {
-	opts := &showOptions{
-		output: types.OutputSimple,
-	}
+	opts := &showOptions{}
 
 	cmd := &cobra.Command{
 		Use:     "show [project-id]",
@@ -30,7 +27,8 @@ func newShowCommand(client *api.Client) *cobra.Command {
 		Long: `Display detailed information about a specific project.
     
 If no project ID is provided, shows the currently active project.
-Can include associated tasks and switch between output formats.`,
+Can include associated tasks and switch between output formats,
+including custom Go templates and field selection.`,
 		Example: `  # Show current project
   tickli project show
   
@@ -41,7 +39,10 @@ Can include associated tasks and switch between output formats.`,
   tickli project show --with-tasks
   
   # Output in JSON format
-  tickli project show -o json`,
+  tickli project show -o json
+  
+  # Output with a custom template
+  tickli project show -o 'template={{.Name}} ({{.ID}})'`,
 		Args:              cobra.MaximumNArgs(1),
 		ValidArgsFunction: completion.ProjectIDs(),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
@@ -62,41 +63,18 @@ Can include associated tasks and switch between output formats.`,
 				if err != nil {
 					return errors.Wrap(err, "failed to get project data")
 				}
-				switch opts.output {
-				case types.OutputSimple:
-					fmt.Println(utils.GetProjectDescription(projectData.Project))
-					for _, task := range projectData.Tasks {
-						fmt.Println(utils.GetTaskDescription(task, projectData.Project.Color))
-					}
-				case types.OutputJSON:
-					jsonData, err := json.MarshalIndent(projectData, "", "  ")
-					if err != nil {
-						return errors.Wrap(err, "failed to marshal output")
-					}
-					fmt.Println(string(jsonData))
-				}
-			} else {
-				project, err := client.GetProject(opts.projectID)
-				if err != nil {
-					return errors.Wrap(err, fmt.Sprintf("failed to get project %s", opts.projectID))
-				}
-				switch opts.output {
-				case types.OutputJSON:
-					jsonData, err := json.MarshalIndent(project, "", "  ")
-					if err != nil {
-						return errors.Wrap(err, "failed to marshal output")
-					}
-					fmt.Println(string(jsonData))
-				case types.OutputSimple:
-					fmt.Println(utils.GetProjectDescription(project))
-				}
+				return render.ProjectData(os.Stdout, projectData, &opts.output)
 			}
-			return nil
+
+			project, err := client.GetProject(opts.projectID)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("failed to get project %s", opts.projectID))
+			}
+			return render.Project(os.Stdout, project, &opts.output)
 		},
 	}
 
 	cmd.Flags().BoolVar(&opts.withTasks, "with-tasks", false, "Include all tasks belonging to this project")
-	cmd.Flags().VarP(&opts.output, "output", "o", "Format for displaying results: simple (human-readable) or json (machine-readable)")
-	_ = cmd.RegisterFlagCompletionFunc("output", types.OutputFormatCompletionFunc)
+	render.AddFlags(cmd, &opts.output)
 	return cmd
 }
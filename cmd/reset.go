@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"github.com/rs/zerolog/log"
+	"github.com/sho0pi/tickli/internal/cache"
 	"github.com/sho0pi/tickli/internal/config"
 	"github.com/spf13/cobra"
 	"os"
@@ -36,6 +37,10 @@ This is useful if you need to reauthenticate with TickTick.`,
 			if err := config.DeleteToken(); err != nil {
 				log.Fatal().Err(err).Msg("Failed to remove access token")
 			}
+			// The cached data belongs to the previous account
+			if err := cache.New(config.CacheDir()).Clear(); err != nil {
+				log.Warn().Err(err).Msg("Failed to clear the cache")
+			}
 
 			log.Info().Msg("Successfully removed access token. Running initialization...")
 			token, err := initTickli()
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/backup"
+	"github.com/sho0pi/tickli/internal/config"
+	"github.com/sho0pi/tickli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+type restoreOptions struct {
+	restore backup.RestoreOptions
+	config  bool
+}
+
+func NewRestoreCommand() *cobra.Command {
+	opts := &restoreOptions{
+		restore: backup.RestoreOptions{
+			Policy: backup.PolicySkip,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:   "restore <archive>",
+		Short: "Restore projects and tasks from a backup archive",
+		Long: `Restore the projects and tasks of an archive created by 'tickli backup'.
+
+Projects are matched by ID, then by name, and created when missing. Tasks are
+matched by ID, then by title within their project, so an archive can also be
+restored into another account. The conflict policy decides what happens to
+the tasks and projects that already exist:
+
+  skip       keep them untouched (default)
+  overwrite  replace them with the archived version
+  duplicate  create the archived tasks again next to the existing ones
+
+Kanban columns cannot be created through the TickTick API, tasks are placed
+in the column of the same name when it exists. Restored projects are open.`,
+		Example: `  # Preview a restore
+  tickli restore tickli-2025-01-01.json.gz --dry-run
+  
+  # Roll tasks back to the archived version
+  tickli restore backup.json --on-conflict overwrite
+  
+  # Restore a single project and the config
+  tickli restore backup.json -P abc123def456 --config`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			archive, err := backup.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			client := utils.LoadClient()
+
+			summary, err := backup.Restore(&client, archive, opts.restore, printRestoreResult(opts.restore.DryRun))
+			if err != nil {
+				return err
+			}
+
+			if opts.config && !opts.restore.DryRun {
+				cfg := archive.Config
+				if id, ok := summary.ProjectIDs[cfg.DefaultProjectID]; ok {
+					cfg.DefaultProjectID = id
+				}
+				if err := config.Save(&cfg); err != nil {
+					return errors.Wrap(err, "failed to restore config")
+				}
+				fmt.Println("Restored config")
+			}
+
+			verb := "created"
+			if opts.restore.DryRun {
+				verb = "to create"
+			}
+			fmt.Printf("\n%d %s, %d updated, %d skipped, %d failed\n",
+				summary.Created, verb, summary.Updated, summary.Skipped, summary.Failed)
+			if summary.Failed > 0 {
+				return fmt.Errorf("%d items failed to restore", summary.Failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().VarP(&opts.restore.Policy, "on-conflict", "c", "What to do with existing items: skip, overwrite or duplicate")
+	_ = cmd.RegisterFlagCompletionFunc("on-conflict", backup.PolicyCompletionFunc)
+	cmd.Flags().StringSliceVarP(&opts.restore.ProjectIDs, "project-id", "P", nil, "Only restore these archived projects (default: all)")
+	cmd.Flags().BoolVar(&opts.config, "config", false, "Also restore the config, such as the default project")
+	cmd.Flags().BoolVarP(&opts.restore.DryRun, "dry-run", "n", false, "Show what would be restored without changing anything")
+
+	return cmd
+}
+
+func printRestoreResult(dryRun bool) func(backup.Result) {
+	prefix := ""
+	if dryRun {
+		prefix = "would be "
+	}
+	return func(r backup.Result) {
+		name := fmt.Sprintf("project %s", r.Project.Name)
+		if r.Task != nil {
+			name = fmt.Sprintf("%q in %s", r.Task.Title, r.Project.Name)
+		}
+		switch r.Action {
+		case backup.ActionFailed:
+			fmt.Printf("! failed %s: %s\n", name, r.Err)
+		case backup.ActionSkipped:
+			fmt.Printf("= skipped %s\n", name)
+		case backup.ActionUpdated:
+			fmt.Printf("~ %supdated %s\n", prefix, name)
+		default:
+			fmt.Printf("+ %s%s %s\n", prefix, r.Action, name)
+		}
+	}
+}
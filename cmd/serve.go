@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/sho0pi/tickli/internal/completion"
+	"github.com/sho0pi/tickli/internal/export"
+	"github.com/sho0pi/tickli/internal/feed"
+	"github.com/sho0pi/tickli/internal/types/task"
+	"github.com/sho0pi/tickli/internal/utils"
+	"github.com/spf13/cobra"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+)
+
+type serveOptions struct {
+	addr string
+	feed feed.Options
+}
+
+func NewServeCommand() *cobra.Command {
+	opts := &serveOptions{
+		feed: feed.Options{
+			Component: export.ICSEvent,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve read-only iCalendar and JSON feeds of your tasks",
+		Long: `Start a local HTTP server publishing read-only feeds of the selected projects.
+
+Calendar apps and dashboards can subscribe to the feeds without their own
+TickTick token. The feeds are cached and refreshed on an interval, use an
+interval of 0 to fetch live data on every request. Responses carry ETag and
+Last-Modified headers so clients only download changed feeds.
+
+By default the server only listens on localhost, bind it to another address
+to share the feeds on your network.`,
+		Example: `  # Serve both feeds of all projects
+  tickli serve --ics --json
+  
+  # Share a calendar of high priority work tasks on the LAN
+  tickli serve --ics --addr 0.0.0.0:8787 -P abc123def456 --priority high
+  
+  # Serve live data, fetched on every request
+  tickli serve --json --interval 0`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if !opts.feed.ICS && !opts.feed.JSON {
+				return errors.New("enable at least one feed with --ics or --json")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := utils.LoadClient()
+			server := feed.NewServer(client.WithRefresh(), opts.feed)
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			httpServer := &http.Server{
+				Addr:              opts.addr,
+				Handler:           server.Handler(),
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+			errs := make(chan error, 2)
+			go func() {
+				errs <- server.Run(ctx)
+			}()
+			go func() {
+				if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+					errs <- err
+				}
+			}()
+			log.Info().Str("addr", fmt.Sprintf("http://%s", opts.addr)).Msg("Serving feeds")
+
+			select {
+			case <-ctx.Done():
+			case err := <-errs:
+				if err != nil {
+					return errors.Wrap(err, "server failed")
+				}
+			}
+
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			return httpServer.Shutdown(shutdownCtx)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.addr, "addr", "127.0.0.1:8787", "Address to listen on")
+	cmd.Flags().BoolVar(&opts.feed.ICS, "ics", false, "Serve iCalendar feeds at /calendar.ics")
+	cmd.Flags().BoolVar(&opts.feed.JSON, "json", false, "Serve JSON feeds at /tasks.json")
+	cmd.Flags().StringSliceVarP(&opts.feed.ProjectIDs, "project-id", "P", nil, "Only serve these projects (default: all open projects)")
+	_ = cmd.RegisterFlagCompletionFunc("project-id", completion.ProjectIDs())
+	cmd.Flags().StringVarP(&opts.feed.Tag, "tag", "t", "", "Only serve tasks with this tag")
+	cmd.Flags().VarP(&opts.feed.Priority, "priority", "p", "Only serve tasks with this priority level or higher")
+	_ = cmd.RegisterFlagCompletionFunc("priority", task.PriorityCompletionFunc)
+	cmd.Flags().DurationVar(&opts.feed.Interval, "interval", 5*time.Minute, "How often to refresh the feeds, 0 to fetch on every request")
+	cmd.Flags().StringVar(&opts.feed.Name, "name", "TickTick", "Calendar name shown by calendar apps")
+	cmd.Flags().Var(&opts.feed.Component, "component", "Calendar component of each task: event or todo")
+	_ = cmd.RegisterFlagCompletionFunc("component", export.ICSComponentCompletionFunc)
+
+	return cmd
+}
@@ -1,24 +1,49 @@
 package subtask
 
 import (
-	"github.com/rs/zerolog/log"
+	"github.com/pkg/errors"
 	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/completion"
+	"github.com/sho0pi/tickli/internal/config"
 	"github.com/sho0pi/tickli/internal/utils"
 	"github.com/spf13/cobra"
 )
 
+var (
+	projectID string
+)
+
 func NewSubtaskCommand() *cobra.Command {
 	var client api.Client
 	cmd := &cobra.Command{
 		Use:   "subtask",
-		Short: "subtask commands",
+		Short: "Work with the checklist items of a task",
+		Long: `View the checklist items (subtasks) of a task.
+
+All subtask commands operate on the current active project by default,
+use the --project-id flag to select another project.`,
+		Example: `  # List the checklist items of a task
+  tickli subtask list abc123def456`,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			client = utils.LoadClient()
-			// Put here to avoid runtime error
-			log.Info().Interface("client", client).Msg("subtask commands")
+			if projectID == "" {
+				cfg, err := config.Load()
+				if err != nil {
+					return errors.Wrap(err, "failed to load config")
+				}
+				projectID = cfg.DefaultProjectID
+			}
 			return nil
 		},
 	}
 
+	cmd.AddCommand(
+		newListCommand(&client),
+		newShowCommand(&client),
+	)
+
+	cmd.PersistentFlags().StringVarP(&projectID, "project-id", "P", "", "select another project")
+	_ = cmd.RegisterFlagCompletionFunc("project-id", completion.ProjectIDs())
+
 	return cmd
 }
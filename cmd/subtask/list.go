@@ -1 +1,47 @@
 package subtask
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/completion"
+	"github.com/sho0pi/tickli/internal/render"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+type listOptions struct {
+	projectID string
+	taskID    string
+	output    render.Options
+}
+
+func newListCommand(client *api.Client) *cobra.Command {
+	opts := &listOptions{}
+	cmd := &cobra.Command{
+		Use:     "list <task-id>",
+		Aliases: []string{"ls"},
+		Short:   "List the checklist items of a task",
+		Example: `  # List checklist items
+  tickli subtask list abc123def456
+  
+  # List checklist items as a table
+  tickli subtask list abc123def456 -o table`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.TaskIDs(projectID),
+		PreRun: func(cmd *cobra.Command, args []string) {
+			opts.projectID = projectID
+			opts.taskID = args[0]
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			t, err := client.GetTask(opts.projectID, opts.taskID)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("failed to get task %s", opts.taskID))
+			}
+			return render.Items(os.Stdout, t.Items, &opts.output)
+		},
+	}
+
+	render.AddFlags(cmd, &opts.output)
+	return cmd
+}
@@ -0,0 +1,49 @@
+package subtask
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/render"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+type showOptions struct {
+	projectID string
+	taskID    string
+	itemID    string
+	output    render.Options
+}
+
+func newShowCommand(client *api.Client) *cobra.Command {
+	opts := &showOptions{}
+	cmd := &cobra.Command{
+		Use:     "show <task-id> <item-id>",
+		Aliases: []string{"info", "get"},
+		Short:   "Display a single checklist item",
+		Example: `  # Show a checklist item as YAML
+  tickli subtask show abc123def456 item789 -o yaml`,
+		Args: cobra.ExactArgs(2),
+		PreRun: func(cmd *cobra.Command, args []string) {
+			opts.projectID = projectID
+			opts.taskID = args[0]
+			opts.itemID = args[1]
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			t, err := client.GetTask(opts.projectID, opts.taskID)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("failed to get task %s", opts.taskID))
+			}
+			for _, item := range t.Items {
+				if item.ID == opts.itemID {
+					return render.Item(os.Stdout, item, &opts.output)
+				}
+			}
+			return fmt.Errorf("checklist item %s not found in task %s", opts.itemID, opts.taskID)
+		},
+	}
+
+	render.AddFlags(cmd, &opts.output)
+	return cmd
+}
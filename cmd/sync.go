@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/gookit/color"
+	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/config"
+	"github.com/sho0pi/tickli/internal/journal"
+	"github.com/sho0pi/tickli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+type syncOptions struct {
+	sync api.SyncOptions
+}
+
+func NewSyncCommand() *cobra.Command {
+	opts := &syncOptions{}
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Send the task changes queued while offline",
+		Long: `Replay the task changes queued while TickTick couldn't be reached, in the
+order they were made.
+
+Tasks created offline get a temporary local-N ID, which is replaced by their
+real ID in the queued changes that follow. A change to a task that was also
+modified on the server meanwhile is a conflict: it stays queued and is
+reported, use --force to apply it anyway or --discard to drop it.
+
+Sync stops at the first network failure, the remaining changes stay queued.`,
+		Example: `  # Show the queued changes and their conflicts
+  tickli sync --dry-run
+  
+  # Send the queued changes
+  tickli sync
+  
+  # Apply conflicting changes over the server copy
+  tickli sync --force`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := utils.LoadClient()
+			j := journal.Open(config.JournalPath())
+
+			applied := 0
+			remaining, err := client.Sync(j, opts.sync, func(r api.SyncResult) {
+				if r.Err == nil && !opts.sync.DryRun {
+					applied++
+				}
+				printSyncResult(r, opts.sync.DryRun)
+			})
+			if err != nil {
+				return err
+			}
+
+			if opts.sync.DryRun {
+				fmt.Printf("\n%d changes queued\n", remaining)
+				return nil
+			}
+			fmt.Printf("\n%d changes synced, %d left in the queue\n", applied, remaining)
+			if remaining > 0 {
+				return fmt.Errorf("%d changes could not be synced", remaining)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.sync.DryRun, "dry-run", "n", false, "List the queued changes and their conflicts without sending them")
+	cmd.Flags().BoolVarP(&opts.sync.Force, "force", "f", false, "Apply changes even when the task changed on the server")
+	cmd.Flags().BoolVar(&opts.sync.Discard, "discard", false, "Drop the changes that conflict or fail instead of keeping them queued")
+	cmd.MarkFlagsMutuallyExclusive("force", "discard")
+
+	return cmd
+}
+
+func printSyncResult(r api.SyncResult, dryRun bool) {
+	e := r.Entry
+	title := e.TaskID
+	if e.Task != nil {
+		title = fmt.Sprintf("%q", e.Task.Title)
+	}
+	line := fmt.Sprintf("#%d %s %s", e.Seq, e.Op, title)
+	if e.Op == journal.OpCreate && r.TaskID != e.TaskID && r.TaskID != "" {
+		line += fmt.Sprintf(" (%s → %s)", e.TaskID, r.TaskID)
+	}
+
+	status := "kept"
+	if !r.Kept {
+		status = "discarded"
+	}
+	switch {
+	case r.Conflict:
+		fmt.Printf("%s %s: conflict, %s (%s)\n", color.Yellow.Sprint("!"), line, r.Err, status)
+	case r.Err != nil:
+		fmt.Printf("%s %s: %s (%s)\n", color.Red.Sprint("✗"), line, r.Err, status)
+	case dryRun:
+		fmt.Printf("%s %s, queued %s\n", color.Gray.Sprint("·"), line, e.Time.Local().Format("2006-01-02 15:04"))
+	default:
+		fmt.Printf("%s %s\n", color.Green.Sprint("✓"), line)
+	}
+}
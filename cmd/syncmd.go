@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/gookit/color"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/completion"
+	"github.com/sho0pi/tickli/internal/config"
+	"github.com/sho0pi/tickli/internal/mdsync"
+	"github.com/sho0pi/tickli/internal/utils"
+	"github.com/spf13/cobra"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+)
+
+type syncMarkdownOptions struct {
+	sync     mdsync.Options
+	watch    bool
+	interval time.Duration
+}
+
+func NewSyncMarkdownCommand() *cobra.Command {
+	opts := &syncMarkdownOptions{}
+	cmd := &cobra.Command{
+		Use:   "sync-md <project> <file.md>",
+		Short: "Keep a Markdown checklist file and a project in sync",
+		Long: `Sync the open tasks of a project with a Markdown checklist file, both ways.
+
+Each task is a top level "- [ ]" line, its ID kept in a trailing HTML comment.
+Dates, priorities and tags use the emoji conventions of 'tickli project export':
+
+  - [ ] Write release notes ⏫ 📅 2025-01-10 #docs <!-- tickli:abc123 -->
+
+Checking a box completes the task, a line without an ID creates a task, and
+tasks added in TickTick are appended to the checklist. Edits on both sides are
+merged field by field against the last sync, when both changed the same field
+TickTick wins. Other lines of the file are left untouched.
+
+Removing a line doesn't delete its task unless --allow-delete is set, the line
+is added back instead. The file is created when missing.
+
+The sync needs the server. Changes are not queued while offline, a failed
+change is sent again by the next sync.`,
+		Example: `  # Sync the sprint checklist of the repo once
+  tickli sync-md "Sprint 12" docs/sprint.md
+  
+  # Show what would change
+  tickli sync-md "Sprint 12" docs/sprint.md --dry-run
+  
+  # Keep syncing while editing the file
+  tickli sync-md "Sprint 12" docs/sprint.md --watch --interval 1m`,
+		Args: cobra.ExactArgs(2),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			if len(args) == 0 {
+				return completion.ProjectIDs()(cmd, args, toComplete)
+			}
+			return []cobra.Completion{"md", "markdown"}, cobra.ShellCompDirectiveFilterFileExt
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := utils.LoadClient()
+			projects, err := client.ListProjects()
+			if err != nil {
+				return errors.Wrap(err, "failed to list projects")
+			}
+			project, ok := api.FindProject(projects, args[0])
+			if !ok {
+				return fmt.Errorf("project not found: %s", args[0])
+			}
+			statePath, err := mdsync.StatePath(config.StateDir(), args[1])
+			if err != nil {
+				return err
+			}
+			syncer := &mdsync.Syncer{
+				Client:    &client,
+				Project:   project,
+				Path:      args[1],
+				StatePath: statePath,
+				Options:   opts.sync,
+			}
+
+			sync := func() error {
+				changes := 0
+				err := syncer.Sync(func(c mdsync.Change) {
+					changes++
+					printSyncMarkdownChange(c, opts.sync.DryRun)
+				})
+				if err == nil && changes == 0 && !opts.watch {
+					fmt.Println("Already in sync")
+				}
+				return err
+			}
+			if !opts.watch {
+				return sync()
+			}
+			return watchMarkdown(args[1], opts.interval, sync)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.watch, "watch", "w", false, "Keep running, syncing when the file changes and every interval")
+	cmd.Flags().DurationVar(&opts.interval, "interval", 30*time.Second, "How often to fetch the project changes in watch mode")
+	cmd.Flags().BoolVar(&opts.sync.AllowDelete, "allow-delete", false, "Delete the tasks whose line was removed from the file")
+	cmd.Flags().BoolVarP(&opts.sync.DryRun, "dry-run", "n", false, "Show the changes without making them")
+	cmd.MarkFlagsMutuallyExclusive("watch", "dry-run")
+
+	return cmd
+}
+
+// watchMarkdown syncs right away, then whenever the file is modified and every interval, until interrupted.
+// Failed syncs are logged and retried, the network may come back.
+func watchMarkdown(path string, interval time.Duration, sync func() error) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	modTime := func() time.Time {
+		info, err := os.Stat(path)
+		if err != nil {
+			return time.Time{}
+		}
+		return info.ModTime()
+	}
+
+	var lastSync, lastModified time.Time
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	fmt.Printf("Watching %s, press Ctrl+C to stop\n", path)
+	for {
+		if modified := modTime(); !modified.Equal(lastModified) || time.Since(lastSync) >= interval {
+			if err := sync(); err != nil {
+				log.Error().Err(err).Msg("Sync failed")
+			}
+			lastSync, lastModified = time.Now(), modTime()
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func printSyncMarkdownChange(c mdsync.Change, dryRun bool) {
+	title := fmt.Sprintf("%q", c.Item.Title)
+	side := "in TickTick"
+	if c.Target == mdsync.TargetFile {
+		side = "in file"
+	}
+	switch {
+	case c.Err != nil:
+		fmt.Printf("%s %s %s %s: %s\n", color.Red.Sprint("✗"), title, c.Action, side, c.Err)
+	case c.Action == mdsync.ActionConflict:
+		fmt.Printf("%s %s changed on both sides (%s), kept TickTick's\n",
+			color.Yellow.Sprint("!"), title, strings.Join(c.Fields, ", "))
+	case dryRun:
+		fmt.Printf("%s %s would be %s %s\n", color.Gray.Sprint("·"), title, c.Action, side)
+	default:
+		fmt.Printf("%s %s %s %s\n", color.Green.Sprint("✓"), title, c.Action, side)
+	}
+}
@@ -0,0 +1,202 @@
+package task
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/gookit/color"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/bulk"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// bulkOptions are the flags of commands working on several tasks at once
+type bulkOptions struct {
+	where   []string
+	dryRun  bool
+	workers int
+	// stdin is set when task IDs were read from stdin
+	stdin bool
+	// completed makes --where select among the completed tasks of the project instead of the open ones
+	completed bool
+}
+
+const bulkHelp = `
+Several task IDs may be given, "-" reads more IDs from stdin, one per line
+(the first field of each line, so 'tickli task list -o tsv' output works).
+--where selects the tasks of the project meeting every condition, given as
+<field><op><value> with the fields tag, title, content, column, priority, due
+and start, and the operators =, !=, <, <=, >, >= and ~ (contains):
+
+  --where tag=sprint-12 --where "due<today" --where priority>=medium
+
+Tasks are worked on concurrently, with a progress bar for long runs and a
+summary of what succeeded and failed. --dry-run lists the tasks without
+changing them.`
+
+func addBulkFlags(cmd *cobra.Command, opts *bulkOptions) {
+	cmd.Flags().StringArrayVarP(&opts.where, "where", "W", nil, "Select the tasks of the project meeting this condition, repeatable")
+	cmd.Flags().BoolVarP(&opts.dryRun, "dry-run", "n", false, "List the tasks that would change without changing them")
+	cmd.Flags().IntVar(&opts.workers, "workers", bulk.DefaultWorkers, "Number of tasks worked on at once")
+}
+
+// bulkTargets resolves the task IDs of the arguments, of stdin when an argument is "-", and the tasks
+// matching --where. Tasks of the project are looked up to show their titles.
+func bulkTargets(client *api.Client, projectID string, args []string, opts *bulkOptions) ([]types.Task, error) {
+	var ids []string
+	for _, arg := range args {
+		if arg != "-" {
+			ids = append(ids, arg)
+			continue
+		}
+		read, err := readIDs(os.Stdin)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read task IDs from stdin")
+		}
+		ids = append(ids, read...)
+		opts.stdin = true
+	}
+
+	data, err := client.GetProjectWithTasks(projectID)
+	if err != nil {
+		if len(opts.where) > 0 {
+			return nil, errors.Wrap(err, "failed to get project tasks")
+		}
+		data = &types.ProjectData{}
+	}
+	candidates := data.Tasks
+	if opts.completed {
+		// Project data only holds open tasks
+		candidates, err = client.ListCompletedTasks([]string{projectID}, time.Unix(0, 0), time.Now())
+		if err != nil && len(opts.where) > 0 {
+			return nil, errors.Wrap(err, "failed to list completed tasks")
+		}
+	}
+
+	var tasks []types.Task
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		idx := slices.IndexFunc(candidates, func(t types.Task) bool { return t.ID == id })
+		if idx < 0 {
+			tasks = append(tasks, types.Task{ID: id, ProjectID: projectID})
+			continue
+		}
+		tasks = append(tasks, candidates[idx])
+	}
+
+	if len(opts.where) > 0 {
+		filter, err := bulk.ParseWhere(opts.where)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range candidates {
+			if !seen[t.ID] && filter.Match(t, data.Columns) {
+				seen[t.ID] = true
+				tasks = append(tasks, t)
+			}
+		}
+	}
+
+	if len(tasks) == 0 {
+		if len(opts.where) > 0 {
+			return nil, errors.New("no tasks match the conditions")
+		}
+		return nil, errors.New("no task IDs given")
+	}
+	return tasks, nil
+}
+
+// readIDs returns the first field of each line, skipping blank lines and # comments
+func readIDs(r io.Reader) ([]string, error) {
+	var ids []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		ids = append(ids, fields[0])
+	}
+	return ids, scanner.Err()
+}
+
+// bulkAction is an operation applied to each target task
+type bulkAction struct {
+	// verb, noun and past name the operation in messages: "complete", "completion", "completed"
+	verb, noun, past string
+	symbol           string
+	run              func(types.Task) error
+}
+
+// runBulk applies the action to the tasks concurrently, printing a line per task and a summary
+func runBulk(tasks []types.Task, opts *bulkOptions, action bulkAction) ([]bulk.Result, error) {
+	if opts.dryRun {
+		fmt.Printf("Would %s %d %s:\n", action.verb, len(tasks), plural(len(tasks), "task", "tasks"))
+		for _, t := range tasks {
+			fmt.Printf("  %s %s\n", t.ID, t.Title)
+		}
+		return nil, nil
+	}
+
+	var progress io.Writer
+	if len(tasks) > 1 && term.IsTerminal(int(os.Stderr.Fd())) {
+		progress = os.Stderr
+	}
+	results := bulk.Run(tasks, opts.workers, progress, action.run)
+
+	queued, skips, failed := 0, 0, 0
+	for _, r := range results {
+		title := ""
+		if r.Task.Title != "" {
+			title = color.Gray.Sprintf(" (%s)", r.Task.Title)
+		}
+		var skip bulk.Skipped
+		switch {
+		case errors.As(r.Err, &skip):
+			skips++
+			fmt.Printf("%s Task %s skipped, %s%s\n", color.Gray.Sprint("·"), r.Task.ID, skip.Reason, title)
+		case errors.Is(r.Err, api.ErrQueued):
+			queued++
+			fmt.Printf("Queued %s of task %s%s\n", action.noun, r.Task.ID, title)
+		case r.Err != nil:
+			failed++
+			fmt.Printf("%s Task %s%s: %s\n", color.Red.Sprint("✗"), r.Task.ID, title, r.Err)
+		case action.symbol == "":
+			fmt.Printf("Task %s %s%s\n", r.Task.ID, action.past, title)
+		default:
+			fmt.Printf("%s Task %s %s%s\n", action.symbol, r.Task.ID, action.past, title)
+		}
+	}
+	if queued > 0 {
+		fmt.Println("Run 'tickli sync' when back online to send the queued changes")
+	}
+	if len(results) > 1 {
+		summary := fmt.Sprintf("%d %s", len(results)-queued-skips-failed, action.past)
+		if skips > 0 {
+			summary += fmt.Sprintf(", %d skipped", skips)
+		}
+		fmt.Printf("\n%s, %d queued, %d failed\n", summary, queued, failed)
+	}
+	if failed > 0 {
+		return results, fmt.Errorf("%d of %d tasks could not be %s", failed, len(results), action.past)
+	}
+	return results, nil
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
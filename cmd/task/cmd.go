@@ -5,6 +5,7 @@ import (
 	"github.com/sho0pi/tickli/internal/api"
 	"github.com/sho0pi/tickli/internal/completion"
 	"github.com/sho0pi/tickli/internal/config"
+	"github.com/sho0pi/tickli/internal/journal"
 	"github.com/sho0pi/tickli/internal/utils"
 	"github.com/spf13/cobra"
 )
@@ -22,7 +23,10 @@ func NewTaskCommand() *cobra.Command {
     
 All task commands operate on the current active project by default.
 You can change the current project with 'tickli project use' or
-specify a different project with the --project-id flag.`,
+specify a different project with the --project-id flag.
+
+When TickTick can't be reached, creating, updating, completing and deleting
+tasks queues the change locally, run 'tickli sync' once back online.`,
 		Example: `  # List all tasks in current project
   tickli task list
   
@@ -33,6 +37,7 @@ specify a different project with the --project-id flag.`,
   tickli task complete abc123def456`,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			client = utils.LoadClient()
+			client.UseJournal(journal.Open(config.JournalPath()))
 			if projectID == "" {
 				cfg, err := config.Load()
 				if err != nil {
@@ -52,6 +57,7 @@ specify a different project with the --project-id flag.`,
 		newListCommand(&client),
 		newUncompleteCommand(&client),
 		newUpdateCommand(&client),
+		newMoveCommand(&client),
 	)
 
 	RegisterProjectOverride(cmd)
@@ -1,49 +1,63 @@
 package task
 
 import (
-	"fmt"
 	"github.com/gookit/color"
-	"github.com/pkg/errors"
 	"github.com/sho0pi/tickli/internal/api"
 	"github.com/sho0pi/tickli/internal/completion"
+	"github.com/sho0pi/tickli/internal/types"
 	"github.com/spf13/cobra"
 )
 
 type completeOptions struct {
 	projectID string
-	taskID    string
+	taskIDs   []string
+	bulk      bulkOptions
 }
 
 func newCompleteCmd(client *api.Client) *cobra.Command {
 	opts := &completeOptions{}
 	cmd := &cobra.Command{
-		Use:   "complete <task-id>",
-		Short: "Mark a task as completed",
-		Long: `Change a task's status to completed.
-    
-Takes a task ID and marks it as done. The task remains in the system
-but will no longer appear in default listings unless using the --all flag.`,
+		Use:   "complete [task-id...]",
+		Short: "Mark tasks as completed",
+		Long: `Change the status of tasks to completed.
+
+Takes task IDs and marks them as done. The tasks remain in the system
+but will no longer appear in default listings unless using the --all flag.
+` + bulkHelp,
 		Example: `  # Complete a task in current project
   tickli task complete abc123def456
   
   # Complete a task in a specific project
-  tickli task complete abc123def456 --project-id xyz789`,
-		Args:              cobra.ExactArgs(1),
+  tickli task complete abc123def456 --project-id xyz789
+  
+  # Close out everything left in the sprint
+  tickli task complete --where tag=sprint-12
+  
+  # Complete the tasks listed in a file
+  tickli task complete - < done.txt`,
+		Args:              cobra.ArbitraryArgs,
 		ValidArgsFunction: completion.TaskIDs(projectID),
 		PreRun: func(cmd *cobra.Command, args []string) {
 			opts.projectID = projectID
-			opts.taskID = args[0]
+			opts.taskIDs = args
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			err := client.CompleteTask(opts.projectID, opts.taskID)
+			tasks, err := bulkTargets(client, opts.projectID, opts.taskIDs, &opts.bulk)
 			if err != nil {
-				return errors.Wrap(err, "failed to complete task")
+				return err
 			}
-
-			fmt.Printf("%s Task %s completed\n", color.Green.Sprint("☑"), opts.taskID)
-			return nil
+			_, err = runBulk(tasks, &opts.bulk, bulkAction{
+				verb: "complete", noun: "completion", past: "completed",
+				symbol: color.Green.Sprint("☑"),
+				run: func(t types.Task) error {
+					return client.CompleteTask(t.ProjectID, t.ID)
+				},
+			})
+			return err
 		},
 	}
 
+	addBulkFlags(cmd, &opts.bulk)
+
 	return cmd
 }
@@ -105,6 +105,10 @@ and tags. At minimum, a title is required unless using interactive mode.`,
 			}
 
 			t, err := client.CreateTask(t)
+			if errors.Is(err, api.ErrQueued) {
+				fmt.Printf("Queued task %s, run 'tickli sync' when back online\n", t.ID)
+				return nil
+			}
 			if err != nil {
 				return errors.Wrap(err, "failed to create task")
 			}
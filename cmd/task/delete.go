@@ -5,25 +5,32 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sho0pi/tickli/internal/api"
 	"github.com/sho0pi/tickli/internal/completion"
+	"github.com/sho0pi/tickli/internal/types"
 	"github.com/spf13/cobra"
 )
 
 type deleteOptions struct {
 	projectID string
-	taskID    string
+	taskIDs   []string
 	force     bool
+	bulk      bulkOptions
 }
 
 func newDeleteCommand(client *api.Client) *cobra.Command {
 	opts := &deleteOptions{}
 	cmd := &cobra.Command{
-		Use:     "delete <task-id>",
+		Use:     "delete [task-id...]",
 		Aliases: []string{"rm", "remove"},
-		Short:   "Remove a task permanently",
-		Long: `Delete a task completely from your TickTick account.
-    
-This operation cannot be undone. By default, you will be asked to confirm
-the deletion unless the --force flag is used.`,
+		Short:   "Remove tasks permanently",
+		Long: `Delete tasks completely from your TickTick account.
+
+A copy of each task is kept in the local trash. Deleted tasks can be
+recreated, with a new ID, by 'tickli undo' or 'tickli trash restore'. By
+default, you will be asked to confirm the deletion unless the --force flag is
+used.
+
+--force is needed when task IDs are read from stdin.
+` + bulkHelp,
 		Example: `  # Delete with confirmation prompt
   tickli task delete abc123def456
   
@@ -31,17 +38,32 @@ the deletion unless the --force flag is used.`,
   tickli task delete abc123def456 --force
   
   # Delete from specific project
-  tickli task delete abc123def456 --project-id xyz789`,
-		Args:              cobra.ExactArgs(1),
+  tickli task delete abc123def456 --project-id xyz789
+  
+  # See what clearing out the sprint would delete
+  tickli task delete --where tag=sprint-12 --dry-run`,
+		Args:              cobra.ArbitraryArgs,
 		ValidArgsFunction: completion.TaskIDs(projectID),
 		PreRun: func(cmd *cobra.Command, args []string) {
 			opts.projectID = projectID
-			opts.taskID = args[0]
+			opts.taskIDs = args
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if !opts.force {
+			tasks, err := bulkTargets(client, opts.projectID, opts.taskIDs, &opts.bulk)
+			if err != nil {
+				return err
+			}
+
+			if !opts.force && !opts.bulk.dryRun {
+				if opts.bulk.stdin {
+					return errors.New("use --force to delete tasks read from stdin, it can't be confirmed")
+				}
 				var confirm string
-				fmt.Printf("Are you sure you want to delete the task %s? (y/N): ", opts.taskID)
+				if len(tasks) == 1 {
+					fmt.Printf("Are you sure you want to delete the task %s? (y/N): ", tasks[0].ID)
+				} else {
+					fmt.Printf("Are you sure you want to delete %d tasks? (y/N): ", len(tasks))
+				}
 				fmt.Scanln(&confirm)
 				if confirm != "y" && confirm != "Y" {
 					fmt.Println("Deletion aborted")
@@ -49,18 +71,18 @@ the deletion unless the --force flag is used.`,
 				}
 			}
 
-			err := client.DeleteTask(opts.projectID, opts.taskID)
-			if err != nil {
-				return errors.Wrap(err, fmt.Sprintf("failed to delete task %s", opts.taskID))
-			}
-
-			fmt.Printf("Task %s deleted\n", opts.taskID)
-
-			return nil
+			_, err = runBulk(tasks, &opts.bulk, bulkAction{
+				verb: "delete", noun: "deletion", past: "deleted",
+				run: func(t types.Task) error {
+					return client.DeleteTask(t.ProjectID, t.ID)
+				},
+			})
+			return err
 		},
 	}
 
 	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Skip confirmation prompt and delete immediately")
+	addBulkFlags(cmd, &opts.bulk)
 
 	return cmd
 }
@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"github.com/rs/zerolog/log"
 	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/render"
 	"github.com/sho0pi/tickli/internal/types"
 	"github.com/sho0pi/tickli/internal/types/project"
 	"github.com/sho0pi/tickli/internal/types/task"
 	"github.com/sho0pi/tickli/internal/utils"
 	"github.com/spf13/cobra"
+	"os"
 	"slices"
 )
 
@@ -20,6 +22,7 @@ type listOptions struct {
 	dueDate   string
 	tag       string
 	projectID string
+	output    render.Options
 }
 
 func fetchProjectColor(client *api.Client, projectID string) project.Color {
@@ -120,7 +123,8 @@ func newListCommand(client *api.Client) *cobra.Command {
 		Long: `Display tasks in the current project or a specified project.
     
 By default, only shows incomplete tasks. You can filter tasks by priority,
-tags, and due date. Results are displayed in an interactive selector.`,
+tags, and due date. Results are displayed in an interactive selector,
+unless an output format is given.`,
 		Example: `  # List all incomplete tasks in current project
   tickli task list
   
@@ -134,7 +138,19 @@ tags, and due date. Results are displayed in an interactive selector.`,
   tickli task list -p high
   
   # List tasks in specific project
-  tickli task list --project-id abc123def456`,
+  tickli task list --project-id abc123def456
+  
+  # Print tasks as an aligned table
+  tickli task list -o table
+  
+  # Stream tasks as newline delimited JSON
+  tickli task list -o ndjson
+  
+  # Print selected fields only
+  tickli task list --fields id,title,dueDate
+  
+  # Print tasks for a status bar
+  tickli task list -o 'template={{priorityColor .Priority .Title}} {{humanize .DueDate}}'`,
 		Args: cobra.NoArgs,
 		PreRun: func(cmd *cobra.Command, args []string) {
 			opts.projectID = projectID
@@ -158,6 +174,10 @@ tags, and due date. Results are displayed in an interactive selector.`,
 			}
 			filteredTasks = taskResult.tasks
 
+			if opts.output.IsSet() {
+				return render.Tasks(os.Stdout, filteredTasks, &opts.output)
+			}
+
 			// Get the project color
 			select {
 			case <-ctx.Done():
@@ -185,6 +205,7 @@ tags, and due date. Results are displayed in an interactive selector.`,
 	_ = cmd.RegisterFlagCompletionFunc("priority", task.PriorityCompletionFunc)
 	cmd.Flags().StringVar(&opts.dueDate, "due", "", "Filter by due date (today, tomorrow, this-week, overdue)")
 	cmd.Flags().BoolVarP(&opts.verbose, "verbose", "v", false, "Show more details for each task in the list")
+	render.AddFlags(cmd, &opts.output)
 
 	return cmd
 }
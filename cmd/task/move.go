@@ -0,0 +1,144 @@
+package task
+
+import (
+	"fmt"
+	"github.com/gookit/color"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/completion"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/spf13/cobra"
+)
+
+type moveOptions struct {
+	projectID string
+	taskIDs   []string
+	column    string
+	to        string
+}
+
+func newMoveCommand(client *api.Client) *cobra.Command {
+	opts := &moveOptions{}
+	cmd := &cobra.Command{
+		Use:   "move <task-id>... (--to <project> | --column <name>)",
+		Short: "Move tasks to another project or kanban column",
+		Long: `Move one or more tasks to another project, or to a kanban column of their
+project.
+
+With --to, tasks keep their title, content, dates, reminders, repeat rule,
+tags and checklist. The project is given by name or ID. Tasks keep their ID
+when the server supports moving tasks; otherwise each task is copied to the
+project and the original deleted, giving it a new ID. The copy is removed
+again if the original can't be deleted, so a failed move leaves the task where
+it was. Tasks leave their kanban column, which belongs to the old project.
+
+With --column, the column is given by name or ID, names complete from the
+columns of the project. See 'tickli project columns list' for the columns of
+a project.`,
+		Example: `  # Move a task to the Work project
+  tickli task move abc123def456 --to Work
+  
+  # Move tasks of another project to the inbox
+  tickli task move abc123 def456 --to Inbox -P xyz789
+  
+  # Move a task to the Done column
+  tickli task move abc123def456 --column Done
+  
+  # Move tasks of another project
+  tickli task move abc123 def456 --column "In Progress" -P xyz789`,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completion.TaskIDs(projectID),
+		PreRun: func(cmd *cobra.Command, args []string) {
+			opts.projectID = projectID
+			opts.taskIDs = args
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.to != "" {
+				return moveToProject(client, opts)
+			}
+
+			data, err := client.GetProjectWithTasks(opts.projectID)
+			if err != nil {
+				return errors.Wrap(err, "failed to get project columns")
+			}
+			column, ok := types.FindColumn(data.Columns, opts.column)
+			if !ok {
+				return fmt.Errorf("column %q not found in the project", opts.column)
+			}
+
+			failed := 0
+			for _, taskID := range opts.taskIDs {
+				err := moveToColumn(client, opts.projectID, taskID, column)
+				if errors.Is(err, api.ErrQueued) {
+					fmt.Printf("Queued move of task %s, run 'tickli sync' when back online\n", taskID)
+					continue
+				}
+				if err != nil {
+					failed++
+					fmt.Printf("%s Task %s: %s\n", color.Red.Sprint("✗"), taskID, err)
+					continue
+				}
+				fmt.Printf("%s Task %s moved to %s\n", color.Green.Sprint("→"), taskID, column.Name)
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d tasks could not be moved", failed, len(opts.taskIDs))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.column, "column", "C", "", "Name or ID of the kanban column to move the tasks to")
+	_ = cmd.RegisterFlagCompletionFunc("column", completion.ColumnNames())
+	cmd.Flags().StringVar(&opts.to, "to", "", "Name or ID of the project to move the tasks to")
+	_ = cmd.RegisterFlagCompletionFunc("to", completion.ProjectIDs())
+	cmd.MarkFlagsOneRequired("to", "column")
+	cmd.MarkFlagsMutuallyExclusive("to", "column")
+
+	return cmd
+}
+
+func moveToColumn(client *api.Client, projectID, taskID string, column types.Column) error {
+	t, err := client.GetTask(projectID, taskID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get task")
+	}
+	if t.ColumnID == column.ID {
+		return nil
+	}
+	t.ColumnID = column.ID
+	_, err = client.UpdateTask(t)
+	return err
+}
+
+func moveToProject(client *api.Client, opts *moveOptions) error {
+	projects, err := client.ListProjects()
+	if err != nil {
+		return errors.Wrap(err, "failed to list projects")
+	}
+	to, ok := api.FindProject(projects, opts.to)
+	if !ok {
+		return fmt.Errorf("project not found: %s", opts.to)
+	}
+
+	failed := 0
+	for _, taskID := range opts.taskIDs {
+		t, err := client.GetTask(opts.projectID, taskID)
+		if err == nil {
+			t, err = client.MoveTask(*t, to.ID)
+		}
+		if err != nil {
+			failed++
+			fmt.Printf("%s Task %s: %s\n", color.Red.Sprint("✗"), taskID, err)
+			continue
+		}
+		if t.ID != taskID {
+			fmt.Printf("%s Task %s moved to %s as %s\n", color.Green.Sprint("→"), taskID, to.Name, t.ID)
+			continue
+		}
+		fmt.Printf("%s Task %s moved to %s\n", color.Green.Sprint("→"), taskID, to.Name)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d tasks could not be moved", failed, len(opts.taskIDs))
+	}
+	return nil
+}
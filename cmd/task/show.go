@@ -1,28 +1,24 @@
 package task
 
 import (
-	"encoding/json"
 	"fmt"
-	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 	"github.com/sho0pi/tickli/internal/api"
 	"github.com/sho0pi/tickli/internal/completion"
-	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/render"
 	"github.com/sho0pi/tickli/internal/types/project"
-	"github.com/sho0pi/tickli/internal/utils"
 	"github.com/spf13/cobra"
+	"os"
 )
 
 type showOptions struct {
 	projectID string
 	taskID    string
-	output    types.OutputFormat
+	output    render.Options
 }
 
 func newShowCommand(client *api.Client) *cobra.Command {
-	opts := &showOptions{
-		output: types.OutputSimple,
-	}
+	opts := &showOptions{}
 	cmd := &cobra.Command{
 		Use:     "show <task-id>",
 		Aliases: []string{"info", "get"},
@@ -30,7 +26,8 @@ func newShowCommand(client *api.Client) *cobra.Command {
 		Long: `Show complete information about a specific task identified by its ID.
     
 Displays title, content, dates, priority, tags, and other properties.
-You can choose between human-readable output or machine-readable JSON.`,
+You can choose between human-readable output, machine-readable JSON
+or a custom Go template.`,
 		Example: `  # Show task details in human-readable format
   tickli task show abc123def456
   
@@ -38,7 +35,13 @@ You can choose between human-readable output or machine-readable JSON.`,
   tickli task show abc123def456 -i xyz789
   
   # Show task details in JSON format
-  tickli task show abc123def456 -o json`,
+  tickli task show abc123def456 -o json
+  
+  # Show only some fields
+  tickli task show abc123def456 --fields id,title,dueDate
+  
+  # Show the task with a custom template
+  tickli task show abc123def456 -o 'template={{.Title}} due {{humanize .DueDate}}'`,
 		Args:              cobra.ExactArgs(1),
 		ValidArgsFunction: completion.TaskIDs(projectID),
 		PreRun: func(cmd *cobra.Command, args []string) {
@@ -54,21 +57,16 @@ You can choose between human-readable output or machine-readable JSON.`,
 				log.Warn().Str("task-id", opts.taskID).Str("project-id", opts.projectID).Msg("task not found")
 				return fmt.Errorf("task %s not found for porject %s", opts.taskID, opts.projectID)
 			}
-			switch opts.output {
-			case types.OutputSimple:
-				fmt.Println(utils.GetTaskDescription(*task, project.DefaultColor))
-			case types.OutputJSON:
-				jsonData, err := json.MarshalIndent(task, "", "  ")
-				if err != nil {
-					return errors.Wrap(err, "failed to marshal output")
-				}
-				fmt.Println(string(jsonData))
+			if err := render.Task(os.Stdout, *task, project.DefaultColor, &opts.output); err != nil {
+				return err
+			}
+			if opts.output.IsDetail() {
+				fmt.Println(task.ID)
 			}
-			fmt.Println(task.ID)
 			return nil
 		},
 	}
 
-	cmd.Flags().VarP(&opts.output, "output", "o", "Display format: simple (human-readable) or json (machine-readable)")
+	render.AddFlags(cmd, &opts.output)
 	return cmd
 }
@@ -1,41 +1,81 @@
 package task
 
 import (
-	"github.com/rs/zerolog/log"
+	"github.com/gookit/color"
+	"github.com/pkg/errors"
 	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/bulk"
 	"github.com/sho0pi/tickli/internal/completion"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/types/task"
 	"github.com/spf13/cobra"
 )
 
 type uncompleteOptions struct {
 	projectID string
-	taskID    string
+	taskIDs   []string
+	bulk      bulkOptions
 }
 
 func newUncompleteCommand(client *api.Client) *cobra.Command {
-	opts := &uncompleteOptions{}
+	opts := &uncompleteOptions{bulk: bulkOptions{completed: true}}
 	cmd := &cobra.Command{
-		Use:   "uncomplete <task-id>",
-		Short: "Mark a completed task as active again",
-		Long: `Change a task's status from completed back to active.
-    
+		Use:   "uncomplete [task-id...]",
+		Short: "Mark completed tasks as active again",
+		Long: `Change the status of tasks from completed back to active.
+
 This command can be used to reactivate tasks that were previously completed
-but need to be worked on again.`,
+but need to be worked on again. --where selects among the completed tasks of
+the project, and tasks that are already open are skipped.
+` + bulkHelp,
 		Example: `  # Reactivate a completed task
   tickli task uncomplete abc123def456
   
   # Reactivate a task in a specific project
-  tickli task uncomplete abc123def456 --project-id xyz789`,
-		Args:              cobra.ExactArgs(1),
+  tickli task uncomplete abc123def456 --project-id xyz789
+  
+  # Reactivate several tasks
+  tickli task uncomplete abc123 def456 ghi789
+  
+  # Reopen the completed tasks of the sprint
+  tickli task uncomplete --where tag=sprint-12`,
+		Args:              cobra.ArbitraryArgs,
 		ValidArgsFunction: completion.TaskIDs(projectID),
 		PreRun: func(cmd *cobra.Command, args []string) {
 			opts.projectID = projectID
-			opts.taskID = args[0]
+			opts.taskIDs = args
 		},
-		Run: func(cmd *cobra.Command, args []string) {
-			log.Warn().Msg("uncomplete command not implemented yet")
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tasks, err := bulkTargets(client, opts.projectID, opts.taskIDs, &opts.bulk)
+			if err != nil {
+				return err
+			}
+			_, err = runBulk(tasks, &opts.bulk, bulkAction{
+				verb: "reactivate", noun: "reactivation", past: "reactivated",
+				symbol: color.Green.Sprint("☐"),
+				run: func(t types.Task) error {
+					return uncompleteTask(client, t)
+				},
+			})
+			return err
 		},
 	}
 
+	addBulkFlags(cmd, &opts.bulk)
+
 	return cmd
 }
+
+func uncompleteTask(client *api.Client, target types.Task) error {
+	t, err := client.GetTask(target.ProjectID, target.ID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get task")
+	}
+	if t.Status != task.StatusComplete {
+		return bulk.Skipped{Reason: "already open"}
+	}
+	t.Status = task.StatusNormal
+	t.CompletedTime = types.TickTickTime{}
+	_, err = client.UpdateTask(t)
+	return err
+}
@@ -2,6 +2,7 @@ package task
 
 import (
 	"fmt"
+	"github.com/gookit/color"
 	"github.com/pkg/errors"
 	"github.com/sho0pi/tickli/internal/api"
 	"github.com/sho0pi/tickli/internal/completion"
@@ -15,7 +16,8 @@ import (
 
 type updateOptions struct {
 	projectID string
-	taskID    string
+	taskIDs   []string
+	bulk      bulkOptions
 
 	title       string
 	content     string
@@ -41,12 +43,14 @@ type updateOptions struct {
 func newUpdateCommand(client *api.Client) *cobra.Command {
 	opts := &updateOptions{}
 	cmd := &cobra.Command{
-		Use:   "update <task-id>",
-		Short: "Modify an existing task's properties",
-		Long: `Update any property of an existing task identified by its ID.
-    
+		Use:   "update [task-id...]",
+		Short: "Modify the properties of existing tasks",
+		Long: `Update any property of existing tasks identified by their ID.
+
 Changes only the properties you specify - others remain unchanged.
-This command allows modifying title, content, priority, dates, and more.`,
+This command allows modifying title, content, priority, dates, and more.
+Every task gets the same changes.
+` + bulkHelp,
 		Example: `  # Update a task's title
   tickli task update abc123def456 -t "New title"
   
@@ -57,70 +61,47 @@ This command allows modifying title, content, priority, dates, and more.`,
   tickli task update abc123def456 --due "next Friday 5pm"
   
   # Update interactively
-  tickli task update abc123def456 -i`,
-		Args:              cobra.ExactArgs(1),
+  tickli task update abc123def456 -i
+  
+  # Push every overdue sprint task to Monday
+  tickli task update --where tag=sprint-12 --where "due<today" --date monday`,
+		Args:              cobra.ArbitraryArgs,
 		ValidArgsFunction: completion.TaskIDs(projectID),
 		PreRun: func(cmd *cobra.Command, args []string) {
 			opts.projectID = projectID
-			opts.taskID = args[0]
+			opts.taskIDs = args
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			t, err := client.GetTask(opts.projectID, opts.taskID)
+			apply, err := updateChanges(cmd, opts)
 			if err != nil {
-				return errors.Wrap(err, fmt.Sprintf("failed to get task with ID %s", opts.taskID))
-			}
-			if cmd.Flags().Changed("title") {
-				t.Title = opts.title
-			}
-			if cmd.Flags().Changed("content") {
-				t.Content = opts.content
+				return err
 			}
-			if cmd.Flags().Changed("desc") {
-				t.Desc = opts.description
-			}
-			if cmd.Flags().Changed("priority") {
-				t.Priority = opts.priority
-			}
-			if cmd.Flags().Changed("tags") {
-				t.Tags = opts.tags
+			tasks, err := bulkTargets(client, opts.projectID, opts.taskIDs, &opts.bulk)
+			if err != nil {
+				return err
 			}
-			if cmd.Flags().Changed("date") {
-				r, err := utils.ParseTimeExpression(opts.date)
-				if err != nil {
-					return errors.Wrap(err, fmt.Sprintf("failed to parse range %s", opts.date))
-				}
-				t.StartDate = types.TickTickTime(r.Start())
-				t.DueDate = types.TickTickTime(r.End())
-				t.IsAllDay = r.IsAllDay()
 
+			var updated *types.Task
+			results, err := runBulk(tasks, &opts.bulk, bulkAction{
+				verb: "update", noun: "update", past: "updated",
+				symbol: color.Green.Sprint("✎"),
+				run: func(target types.Task) error {
+					t, err := client.GetTask(target.ProjectID, target.ID)
+					if err != nil {
+						return errors.Wrap(err, "failed to get task")
+					}
+					apply(t)
+					t, err = client.UpdateTask(t)
+					if len(tasks) == 1 {
+						updated = t
+					}
+					return err
+				},
+			})
+			if len(results) == 1 && results[0].Err == nil && updated != nil {
+				fmt.Println(utils.GetTaskDescription(*updated, project.DefaultColor))
 			}
-			if opts.startDate != "" {
-				startDate, err := time.Parse(time.RFC3339, opts.startDate)
-				if err != nil {
-					return errors.Wrap(err, "failed to parse start date")
-				}
-				t.StartDate = types.TickTickTime(startDate)
-			}
-			if opts.dueDate != "" {
-				dueDate, err := time.Parse(time.RFC3339, opts.dueDate)
-				if err != nil {
-					return errors.Wrap(err, "failed to parse due date")
-				}
-				t.DueDate = types.TickTickTime(dueDate)
-			}
-			if opts.timeZone != "" {
-				t.TimeZone = opts.timeZone
-			}
-			if cmd.Flags().Changed("all-day") {
-				t.IsAllDay = opts.allDay
-			}
-			t, err = client.UpdateTask(t)
-			if err != nil {
-				return errors.Wrap(err, fmt.Sprintf("failed to update task %s", opts.taskID))
-			}
-			fmt.Printf("Task %s updated successfully\n", t.ID)
-			fmt.Println(utils.GetTaskDescription(*t, project.DefaultColor))
-			return nil
+			return err
 		},
 	}
 
@@ -143,6 +124,64 @@ This command allows modifying title, content, priority, dates, and more.`,
 	cmd.Flags().Var(&opts.priority, "priority", "Change task importance: none, low, medium, high")
 	_ = cmd.RegisterFlagCompletionFunc("priority", task.PriorityCompletionFunc)
 	cmd.Flags().BoolVarP(&opts.interactive, "interactive", "i", false, "Update task by answering prompts")
+	addBulkFlags(cmd, &opts.bulk)
 
 	return cmd
 }
+
+// updateChanges parses the flags once into the changes applied to every task
+func updateChanges(cmd *cobra.Command, opts *updateOptions) (func(t *types.Task), error) {
+	var changes []func(t *types.Task)
+	if cmd.Flags().Changed("title") {
+		changes = append(changes, func(t *types.Task) { t.Title = opts.title })
+	}
+	if cmd.Flags().Changed("content") {
+		changes = append(changes, func(t *types.Task) { t.Content = opts.content })
+	}
+	if cmd.Flags().Changed("desc") {
+		changes = append(changes, func(t *types.Task) { t.Desc = opts.description })
+	}
+	if cmd.Flags().Changed("priority") {
+		changes = append(changes, func(t *types.Task) { t.Priority = opts.priority })
+	}
+	if cmd.Flags().Changed("tags") {
+		changes = append(changes, func(t *types.Task) { t.Tags = opts.tags })
+	}
+	if cmd.Flags().Changed("date") {
+		r, err := utils.ParseTimeExpression(opts.date)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to parse range %s", opts.date))
+		}
+		changes = append(changes, func(t *types.Task) {
+			t.StartDate = types.TickTickTime(r.Start())
+			t.DueDate = types.TickTickTime(r.End())
+			t.IsAllDay = r.IsAllDay()
+		})
+	}
+	if opts.startDate != "" {
+		startDate, err := time.Parse(time.RFC3339, opts.startDate)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse start date")
+		}
+		changes = append(changes, func(t *types.Task) { t.StartDate = types.TickTickTime(startDate) })
+	}
+	if opts.dueDate != "" {
+		dueDate, err := time.Parse(time.RFC3339, opts.dueDate)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse due date")
+		}
+		changes = append(changes, func(t *types.Task) { t.DueDate = types.TickTickTime(dueDate) })
+	}
+	if opts.timeZone != "" {
+		changes = append(changes, func(t *types.Task) { t.TimeZone = opts.timeZone })
+	}
+	if cmd.Flags().Changed("all-day") {
+		changes = append(changes, func(t *types.Task) { t.IsAllDay = opts.allDay })
+	}
+
+	return func(t *types.Task) {
+		for _, change := range changes {
+			change(t)
+		}
+	}, nil
+}
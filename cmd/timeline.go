@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/completion"
+	"github.com/sho0pi/tickli/internal/render"
+	"github.com/sho0pi/tickli/internal/timeline"
+	"github.com/sho0pi/tickli/internal/utils"
+	"github.com/spf13/cobra"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type timelineOptions struct {
+	from    string
+	to      string
+	scale   timeline.Scale
+	groupBy timeline.GroupBy
+	format  timeline.Format
+	width   int
+	file    string
+}
+
+func NewTimelineCommand() *cobra.Command {
+	opts := &timelineOptions{
+		scale:   timeline.ScaleDay,
+		groupBy: timeline.GroupByProject,
+	}
+	cmd := &cobra.Command{
+		Use:   "timeline [project...]",
+		Short: "Draw tasks as bars over time",
+		Long: `Draw each task as a bar from its start date to its due date, one cell per
+day or per week, grouped by project or by kanban column.
+
+Tasks with only a start or a due date take a single cell, tasks with neither
+are left out. Today is marked in the header and down the rows, and overdue
+tasks are drawn hatched in red. Every open project is shown unless projects are
+given by ID or name.
+
+The range starts a week ago and spans four weeks on a day scale, twelve weeks
+on a week scale. --from and --to accept dates and natural language.
+
+The timeline is drawn for the terminal by default, plain when the output is
+not a terminal. Use --format svg for an image, which is picked automatically
+when writing to a .svg file.`,
+		Example: `  # The next few weeks of every project
+  tickli timeline
+  
+  # A quarter of the release project, by week and column
+  tickli timeline Release --scale week --group-by column --from "jan 1" --to "mar 31"
+  
+  # Save the timeline as an image
+  tickli timeline --write timeline.svg`,
+		ValidArgsFunction: completion.ProjectIDs(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			now := time.Now()
+			span := 27
+			if opts.scale == timeline.ScaleWeek {
+				span = 83
+			}
+			from := timeline.Day(now).AddDate(0, 0, -7)
+			var err error
+			if opts.from != "" {
+				if from, err = parseDay(opts.from); err != nil {
+					return err
+				}
+			}
+			to := from.AddDate(0, 0, span)
+			if opts.to != "" {
+				if to, err = parseDay(opts.to); err != nil {
+					return err
+				}
+			}
+			if to.Before(from) {
+				return fmt.Errorf("the timeline ends before it starts: %s to %s", from.Format("Jan 2 2006"), to.Format("Jan 2 2006"))
+			}
+
+			client := utils.LoadClient()
+			projects, err := client.ListProjectsData(args...)
+			if err != nil {
+				return errors.Wrap(err, "failed to get projects")
+			}
+			tl := timeline.Build(projects, timeline.Options{
+				From:    from,
+				To:      to,
+				Scale:   opts.scale,
+				GroupBy: opts.groupBy,
+				Now:     now,
+			})
+
+			out := os.Stdout
+			if opts.file != "" && opts.file != "-" {
+				if opts.format == "" && strings.EqualFold(filepath.Ext(opts.file), ".svg") {
+					opts.format = timeline.FormatSVG
+				}
+				if out, err = os.Create(opts.file); err != nil {
+					return errors.Wrap(err, "failed to create timeline file")
+				}
+				defer out.Close()
+				lipgloss.SetColorProfile(termenv.Ascii)
+			}
+
+			if opts.format == timeline.FormatSVG {
+				return errors.Wrap(timeline.SVG(out, tl), "failed to write timeline")
+			}
+			width := opts.width
+			if width <= 0 {
+				width = render.TerminalWidth(out)
+			}
+			if width <= 0 {
+				width = 120
+			}
+			_, err = fmt.Fprintln(out, timeline.Text(tl, width))
+			return errors.Wrap(err, "failed to write timeline")
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.from, "from", "", "First day of the timeline, a week ago by default")
+	cmd.Flags().StringVar(&opts.to, "to", "", "Last day of the timeline")
+	cmd.Flags().VarP(&opts.scale, "scale", "s", "Length of a cell: day or week")
+	_ = cmd.RegisterFlagCompletionFunc("scale", timeline.ScaleCompletionFunc)
+	cmd.Flags().VarP(&opts.groupBy, "group-by", "g", "Group the bars by project or column")
+	_ = cmd.RegisterFlagCompletionFunc("group-by", timeline.GroupByCompletionFunc)
+	cmd.Flags().VarP(&opts.format, "format", "f", "Output format: text or svg")
+	_ = cmd.RegisterFlagCompletionFunc("format", timeline.FormatCompletionFunc)
+	cmd.Flags().IntVar(&opts.width, "width", 0, "Width of the text timeline, the terminal width by default")
+	cmd.Flags().StringVarP(&opts.file, "write", "w", "", "Write the timeline to this file instead of stdout")
+
+	return cmd
+}
+
+// parseDay reads a date or a natural language expression as the day it starts on
+func parseDay(expr string) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", expr, time.Local); err == nil {
+		return t, nil
+	}
+	r, err := utils.ParseTimeExpression(expr)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "invalid date: %s", expr)
+	}
+	return timeline.Day(r.Start()), nil
+}
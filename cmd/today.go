@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/gookit/color"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/sho0pi/tickli/internal/agenda"
+	"github.com/sho0pi/tickli/internal/config"
+	"github.com/sho0pi/tickli/internal/dashboard"
+	"github.com/sho0pi/tickli/internal/timeline"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/utils"
+	"github.com/spf13/cobra"
+	"io"
+	"os"
+	"slices"
+	"time"
+)
+
+type todayOptions struct {
+	sections []string
+	soonDays int
+	limit    int
+}
+
+func NewTodayCommand() *cobra.Command {
+	opts := &todayOptions{}
+	cmd := &cobra.Command{
+		Use:   "today",
+		Short: "Show what needs attention today across all projects",
+		Long: `Show a dashboard of every open project, in compact sections with counts:
+
+  overdue    open tasks due before today
+  today      tasks due today
+  soon       tasks due in the next few days
+  priority   high priority tasks without a date
+  completed  tasks completed today
+
+The sections shown, their order, how many days "soon" covers and how many
+tasks each section lists are read from the "today" entry of the config file:
+
+  today:
+    sections: [overdue, today, soon, priority, completed]
+    soon_days: 3
+    limit: 10
+
+Flags override the config for a single run.`,
+		Example: `  # The morning overview
+  tickli today
+  
+  # Only what is late or due today, without a limit
+  tickli today --sections overdue,today --limit 0`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return errors.Wrap(err, "failed to load config")
+			}
+			if !cmd.Flags().Changed("sections") {
+				opts.sections = cfg.Today.Sections
+			}
+			if !cmd.Flags().Changed("soon") {
+				opts.soonDays = cfg.Today.SoonDays
+			}
+			if !cmd.Flags().Changed("limit") {
+				opts.limit = cfg.Today.Limit
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kinds, err := dashboard.ParseKinds(opts.sections)
+			if err != nil {
+				return err
+			}
+
+			client := utils.LoadClient()
+			projects, err := client.ListProjectsData()
+			if err != nil {
+				return errors.Wrap(err, "failed to get projects")
+			}
+
+			now := time.Now()
+			var completed []types.Task
+			if slices.Contains(kinds, dashboard.KindCompleted) {
+				ids := make([]string, len(projects))
+				for i, data := range projects {
+					ids[i] = data.Project.ID
+				}
+				if completed, err = client.ListCompletedTasks(ids, timeline.Day(now), now); err != nil {
+					log.Warn().Err(err).Msg("Could not list the tasks completed today")
+					kinds = slices.DeleteFunc(kinds, func(k dashboard.Kind) bool { return k == dashboard.KindCompleted })
+				}
+			}
+
+			sections := dashboard.Build(projects, completed, dashboard.Options{
+				Sections: kinds,
+				SoonDays: opts.soonDays,
+				Limit:    opts.limit,
+				Now:      now,
+			})
+			byID := make(map[string]types.Project, len(projects))
+			for _, data := range projects {
+				byID[data.Project.ID] = data.Project
+			}
+			printDashboard(os.Stdout, sections, byID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&opts.sections, "sections", "s", config.DefaultTodaySections, "Sections to show, in order")
+	_ = cmd.RegisterFlagCompletionFunc("sections", cobra.FixedCompletions(config.DefaultTodaySections, cobra.ShellCompDirectiveNoFileComp))
+	cmd.Flags().IntVar(&opts.soonDays, "soon", 3, "Number of days after today covered by the soon section")
+	cmd.Flags().IntVarP(&opts.limit, "limit", "n", 10, "Most tasks listed per section, 0 for all")
+
+	return cmd
+}
+
+func printDashboard(w io.Writer, sections []dashboard.Section, projects map[string]types.Project) {
+	for i, s := range sections {
+		count := color.Gray.Sprint(s.Total)
+		switch {
+		case s.Kind == dashboard.KindOverdue && s.Total > 0:
+			count = color.Red.Sprint(s.Total)
+		case s.Kind == dashboard.KindCompleted && s.Total > 0:
+			count = color.Green.Sprint(s.Total)
+		case s.Total > 0:
+			count = color.Cyan.Sprint(s.Total)
+		}
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s %s\n", color.Bold.Sprint(s.Title), count)
+
+		for _, e := range s.Entries {
+			p, ok := projects[e.ProjectID]
+			if !ok {
+				p = types.Project{Name: e.Project}
+			}
+			summary := utils.GetTaskSummary(e.Task, p)
+			if s.Kind == dashboard.KindCompleted {
+				summary = color.Green.Sprint("✔ ") + summary
+			}
+			if when := dashboardWhen(s.Kind, e); when != "" {
+				summary = when + " " + summary
+			}
+			fmt.Fprintln(w, "  "+summary)
+		}
+		if more := s.Total - len(s.Entries); more > 0 {
+			fmt.Fprintln(w, color.Gray.Sprintf("  … and %d more", more))
+		}
+	}
+}
+
+// dashboardWhen formats the date of an entry for its section, padded to line up
+func dashboardWhen(kind dashboard.Kind, e agenda.Entry) string {
+	var when string
+	switch {
+	case kind == dashboard.KindPriority:
+		return ""
+	case kind == dashboard.KindCompleted:
+		when = e.Time.Format("15:04")
+	case kind == dashboard.KindOverdue:
+		when = e.Time.Format("Jan 2")
+	case kind == dashboard.KindSoon && e.IsAllDay:
+		when = e.Time.Format("Mon 2")
+	case kind == dashboard.KindSoon:
+		when = e.Time.Format("Mon 15:04")
+	case e.IsAllDay:
+		when = "all day"
+	default:
+		when = e.Time.Format("15:04")
+	}
+	when = fmt.Sprintf("%-9s", when)
+	if e.Overdue {
+		return color.Red.Sprint(when)
+	}
+	return color.Gray.Sprint(when)
+}
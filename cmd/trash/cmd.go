@@ -0,0 +1,34 @@
+package trash
+
+import (
+	"github.com/sho0pi/tickli/internal/trash"
+	"github.com/sho0pi/tickli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// NewTrashCommand returns a cobra command for `trash` subcommands
+func NewTrashCommand() *cobra.Command {
+	var store trash.Trash
+	cmd := &cobra.Command{
+		Use:   "trash",
+		Short: "Restore tasks and projects deleted with tickli",
+		Long: `Restore tasks and projects deleted with tickli from the local trash.
+
+The TickTick trash isn't reachable through the Open API, so tickli keeps a copy
+of every task and project before deleting it, projects with their tasks and
+kanban columns. Items are kept for trash.retention_days of the config (30 by
+default, 0 keeps them until purged).`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			store = *utils.OpenTrash()
+			return nil
+		},
+	}
+
+	cmd.AddCommand(
+		newListCommand(&store),
+		newRestoreCommand(&store),
+		newPurgeCommand(&store),
+	)
+
+	return cmd
+}
@@ -0,0 +1,82 @@
+package trash
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gookit/color"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/trash"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/spf13/cobra"
+	"time"
+)
+
+type listOptions struct {
+	output types.OutputFormat
+}
+
+func newListCommand(store *trash.Trash) *cobra.Command {
+	opts := &listOptions{}
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List the deleted tasks and projects",
+		Long: `List the tasks and projects in the trash, most recently deleted first, with
+the ID they had, which 'tickli trash restore' takes.`,
+		Example: `  # What was deleted lately
+  tickli trash list
+  
+  # The full copies as JSON
+  tickli trash list -o json`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			switch opts.output {
+			case "", types.OutputSimple, types.OutputJSON:
+				return nil
+			default:
+				return fmt.Errorf("trash output must be simple or json, got %s", opts.output)
+			}
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			items, err := store.List()
+			if err != nil {
+				return errors.Wrap(err, "failed to read trash")
+			}
+
+			if opts.output == types.OutputJSON {
+				data, err := json.MarshalIndent(items, "", "  ")
+				if err != nil {
+					return errors.Wrap(err, "failed to marshal trash")
+				}
+				fmt.Println(string(data))
+				return nil
+			}
+			if len(items) == 0 {
+				fmt.Println("The trash is empty")
+				return nil
+			}
+			for _, item := range items {
+				printItem(item, store.Retention())
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().VarP(&opts.output, "output", "o", "Display format: simple or json")
+	_ = cmd.RegisterFlagCompletionFunc("output", types.SimpleOrJSONCompletionFunc)
+
+	return cmd
+}
+
+func printItem(item trash.Item, retention time.Duration) {
+	title := item.Title()
+	if item.Kind == trash.KindProject {
+		title += fmt.Sprintf(" (%d tasks)", len(item.Project.Tasks))
+	}
+	when := "deleted " + item.DeletedAt.Local().Format("2006-01-02 15:04")
+	if retention > 0 {
+		days := int(time.Until(item.DeletedAt.Add(retention)).Hours()/24) + 1
+		when += fmt.Sprintf(", purged in %d days", days)
+	}
+	fmt.Printf("%s  %-7s %s  %s\n", item.ID, item.Kind, title, color.Gray.Sprint(when))
+}
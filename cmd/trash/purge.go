@@ -0,0 +1,76 @@
+package trash
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/completion"
+	"github.com/sho0pi/tickli/internal/trash"
+	"github.com/spf13/cobra"
+	"time"
+)
+
+type purgeOptions struct {
+	olderThan time.Duration
+	force     bool
+}
+
+func newPurgeCommand(store *trash.Trash) *cobra.Command {
+	opts := &purgeOptions{}
+	cmd := &cobra.Command{
+		Use:   "purge [id...]",
+		Short: "Drop items from the trash for good",
+		Long: `Drop the given items from the trash, those deleted more than --older-than
+ago, or the whole trash. Purged items can't be restored anymore.
+
+Items past trash.retention_days of the config are purged on their own whenever
+something is deleted.`,
+		Example: `  # Empty the trash
+  tickli trash purge
+  
+  # Drop what was deleted more than a week ago
+  tickli trash purge --older-than 168h
+  
+  # Drop a single item
+  tickli trash purge abc123def456`,
+		Args:              cobra.ArbitraryArgs,
+		ValidArgsFunction: completion.TrashIDs(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				for _, id := range args {
+					if _, err := store.Get(id); err != nil {
+						return err
+					}
+					if err := store.Remove(id); err != nil {
+						return err
+					}
+				}
+				fmt.Printf("Purged %d items\n", len(args))
+				return nil
+			}
+
+			var before time.Time
+			if opts.olderThan > 0 {
+				before = time.Now().Add(-opts.olderThan)
+			} else if !opts.force {
+				var confirm string
+				fmt.Print("Are you sure you want to empty the trash? (y/N): ")
+				fmt.Scanln(&confirm)
+				if confirm != "y" && confirm != "Y" {
+					fmt.Println("Purge aborted")
+					return nil
+				}
+			}
+			purged, err := store.Purge(before)
+			if err != nil {
+				return errors.Wrap(err, "failed to purge trash")
+			}
+			fmt.Printf("Purged %d items\n", purged)
+			return nil
+		},
+	}
+
+	cmd.Flags().DurationVar(&opts.olderThan, "older-than", 0, "Only drop the items deleted longer ago than this")
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Empty the trash without confirmation")
+
+	return cmd
+}
@@ -0,0 +1,84 @@
+package trash
+
+import (
+	"fmt"
+	"github.com/gookit/color"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/completion"
+	"github.com/sho0pi/tickli/internal/trash"
+	"github.com/sho0pi/tickli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+type restoreOptions struct {
+	to string
+}
+
+func newRestoreCommand(store *trash.Trash) *cobra.Command {
+	opts := &restoreOptions{}
+	cmd := &cobra.Command{
+		Use:   "restore <id>...",
+		Short: "Recreate deleted tasks and projects",
+		Long: `Recreate tasks and projects of the trash, by the ID they had before being
+deleted, and remove them from the trash.
+
+Restored tasks and projects get new IDs. Tasks go back to their project, or to
+the one given with --to when it was deleted too. Projects come back with the
+open tasks they had, outside their kanban columns which can't be recreated
+through the API. The deletion is marked undone in the history, and undoing a
+deletion with 'tickli undo' removes the item from the trash.`,
+		Example: `  # Bring back a deleted project with its tasks
+  tickli trash restore abc123def456
+  
+  # Restore a task into the inbox
+  tickli trash restore abc123def456 --to Inbox`,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completion.TrashIDs(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := utils.LoadClient()
+			toProjectID := ""
+			if opts.to != "" {
+				projects, err := client.ListProjects()
+				if err != nil {
+					return errors.Wrap(err, "failed to list projects")
+				}
+				to, ok := api.FindProject(projects, opts.to)
+				if !ok {
+					return fmt.Errorf("project not found: %s", opts.to)
+				}
+				toProjectID = to.ID
+			}
+
+			failed := 0
+			for _, id := range args {
+				if err := restoreItem(&client, store, id, toProjectID); err != nil {
+					failed++
+					fmt.Printf("%s %s: %s\n", color.Red.Sprint("✗"), id, err)
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d items could not be restored", failed, len(args))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.to, "to", "", "Name or ID of the project to restore tasks into")
+	_ = cmd.RegisterFlagCompletionFunc("to", completion.ProjectIDs())
+
+	return cmd
+}
+
+func restoreItem(client *api.Client, store *trash.Trash, id, toProjectID string) error {
+	item, err := store.Get(id)
+	if err != nil {
+		return err
+	}
+	result, err := client.Restore(item, toProjectID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s Restored %s %q: %s\n", color.Green.Sprint("↶"), item.Kind, item.Title(), result.Message)
+	return store.Remove(id)
+}
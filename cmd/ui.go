@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"github.com/sho0pi/tickli/internal/tui"
+	"github.com/sho0pi/tickli/internal/utils"
+	"github.com/spf13/cobra"
+	"time"
+)
+
+type uiOptions struct {
+	interval time.Duration
+}
+
+func NewUICommand() *cobra.Command {
+	opts := &uiOptions{}
+	cmd := &cobra.Command{
+		Use:   "ui",
+		Short: "Browse and edit tasks in a full-screen terminal app",
+		Long: `Open a full-screen app with your projects in a sidebar, the tasks of the
+selected project, and the details and checklist of the selected task.
+
+Keys:
+  ↑↓ j k     move in the focused pane
+  tab ← →    switch between projects, tasks and checklist
+  n          create a task in the selected project
+  e          edit the title of the task
+  x space    complete the task, or toggle the checklist item
+  p          cycle the priority: none, low, medium, high
+  m          move the task to another project
+  d          delete the task
+  r          refresh now
+  q          quit
+
+Projects and tasks are refreshed in the background every --interval.`,
+		Example: `  # Open the app
+  tickli ui
+  
+  # Refresh every 30 seconds
+  tickli ui --interval 30s`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := utils.LoadClient()
+			return tui.Run(&client, opts.interval)
+		},
+	}
+
+	cmd.Flags().DurationVarP(&opts.interval, "interval", "i", 2*time.Minute, "How often to refresh projects and tasks in the background, 0 to disable")
+
+	return cmd
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/gookit/color"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/config"
+	"github.com/sho0pi/tickli/internal/history"
+	"github.com/sho0pi/tickli/internal/utils"
+	"github.com/spf13/cobra"
+	"maps"
+	"strconv"
+)
+
+type undoOptions struct {
+	count  int
+	dryRun bool
+}
+
+func NewUndoCommand() *cobra.Command {
+	opts := &undoOptions{count: 1}
+	cmd := &cobra.Command{
+		Use:   "undo [n]",
+		Short: "Revert the last changes tickli made",
+		Long: `Revert the last n changes recorded in 'tickli history', newest first (1 by default).
+
+Created tasks are deleted, updates and completions restore the task as it was,
+moves send the task back and deleted tasks are recreated. Deleted projects are
+recreated with the open tasks they had, outside their kanban columns which
+can't be recreated. A created project is only deleted while it has no tasks.
+
+Recreated tasks and projects get new IDs. Undo stops at the first change that
+can't be reverted, the changes before it are left as they are.`,
+		Example: `  # Revert the last change
+  tickli undo
+  
+  # See what reverting the last 3 changes would do
+  tickli undo 3 --dry-run
+  
+  # Bring back a deleted project with its tasks
+  tickli history
+  tickli undo`,
+		Args: cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return nil
+			}
+			n, err := strconv.Atoi(args[0])
+			if err != nil || n < 1 {
+				return fmt.Errorf("the number of changes must be a positive number, got %s", args[0])
+			}
+			opts.count = n
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			hist := history.Open(config.HistoryPath())
+			entries, err := hist.Entries()
+			if err != nil {
+				return errors.Wrap(err, "failed to read history")
+			}
+
+			var targets []history.Entry
+			for i := len(entries) - 1; i >= 0 && len(targets) < opts.count; i-- {
+				if !entries[i].Undone {
+					targets = append(targets, entries[i])
+				}
+			}
+			if len(targets) == 0 {
+				fmt.Println("Nothing to undo")
+				return nil
+			}
+
+			if opts.dryRun {
+				fmt.Printf("Would undo %d changes:\n", len(targets))
+				for _, e := range targets {
+					printHistoryEntry(e)
+				}
+				return nil
+			}
+
+			client := utils.LoadClient()
+			ids := history.Replaced(entries)
+			for _, e := range targets {
+				result, err := client.Undo(e, ids)
+				if err != nil {
+					fmt.Printf("%s #%d %s: %s\n", color.Red.Sprint("✗"), e.ID, e.Summary(), err)
+					return fmt.Errorf("change #%d could not be undone", e.ID)
+				}
+				if err := hist.MarkUndone(e.ID, result.Replaced); err != nil {
+					return errors.Wrap(err, "failed to update history")
+				}
+				maps.Copy(ids, result.Replaced)
+				fmt.Printf("%s #%d %s: %s\n", color.Green.Sprint("↶"), e.ID, e.Summary(), result.Message)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.dryRun, "dry-run", "n", false, "List the changes that would be undone without reverting them")
+
+	return cmd
+}
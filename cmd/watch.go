@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/sho0pi/tickli/internal/completion"
+	"github.com/sho0pi/tickli/internal/config"
+	"github.com/sho0pi/tickli/internal/utils"
+	"github.com/sho0pi/tickli/internal/watch"
+	"github.com/spf13/cobra"
+	"os"
+	"os/signal"
+	"slices"
+	"time"
+)
+
+type watchOptions struct {
+	interval time.Duration
+	hooks    []string
+	on       []string
+	once     bool
+}
+
+func NewWatchCommand() *cobra.Command {
+	opts := &watchOptions{}
+	cmd := &cobra.Command{
+		Use:   "watch [project...]",
+		Short: "Poll projects for task changes and run hooks",
+		Long: `Poll projects for task changes, printing each change as a JSON line.
+
+Events are "created", "completed", "updated" (with the changed fields) and
+"deleted", found by comparing the tasks with the previous poll. Every open
+project is watched unless projects are given by ID or name.
+
+The last state is kept between runs, so changes made while not watching are
+reported by the first poll. The first run ever only records the state.
+
+Hooks given with --exec run through the shell for each event, after it is
+printed. The event JSON is written on their stdin, and the TICKLI_EVENT,
+TICKLI_PROJECT_ID, TICKLI_PROJECT, TICKLI_TASK_ID, TICKLI_TASK_TITLE,
+TICKLI_TASK_PRIORITY and TICKLI_TASK_TAGS environment variables are set. Hook
+output goes to stderr, keeping stdout valid NDJSON.`,
+		Example: `  # Print every change of the account
+  tickli watch
+  
+  # Notify when a teammate completes a sprint task
+  tickli watch "Sprint 12" --on completed --exec 'notify-send "Done: $TICKLI_TASK_TITLE"'
+  
+  # Report the changes since the last run, from cron
+  tickli watch --once >> ~/tickli-events.ndjson`,
+		ValidArgsFunction: completion.ProjectIDs(),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			for _, on := range opts.on {
+				switch watch.EventType(on) {
+				case watch.EventCreated, watch.EventCompleted, watch.EventUpdated, watch.EventDeleted:
+				default:
+					return fmt.Errorf("invalid event type: %s", on)
+				}
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := utils.LoadClient()
+			projectIDs, err := client.ResolveProjectIDs(args...)
+			if err != nil {
+				return errors.Wrap(err, "failed to resolve projects")
+			}
+			w := &watch.Watcher{
+				Client:     &client,
+				ProjectIDs: projectIDs,
+				StatePath:  watch.StatePath(config.StateDir(), projectIDs),
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			enc := json.NewEncoder(os.Stdout)
+			poll := func() error {
+				events, err := w.Poll()
+				for _, e := range events {
+					if len(opts.on) > 0 && !slices.Contains(opts.on, string(e.Type)) {
+						continue
+					}
+					if err := enc.Encode(e); err != nil {
+						return err
+					}
+					for _, hook := range opts.hooks {
+						if err := watch.RunHook(ctx, hook, e, os.Stderr, os.Stderr); err != nil {
+							log.Error().Err(err).Str("task", e.Task.ID).Msg("Hook failed")
+						}
+					}
+				}
+				return err
+			}
+
+			if opts.once {
+				return poll()
+			}
+			ticker := time.NewTicker(opts.interval)
+			defer ticker.Stop()
+			for {
+				if err := poll(); err != nil {
+					log.Error().Err(err).Msg("Poll failed")
+				}
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-ticker.C:
+				}
+			}
+		},
+	}
+
+	cmd.Flags().DurationVarP(&opts.interval, "interval", "i", time.Minute, "How often to poll the projects")
+	cmd.Flags().StringArrayVarP(&opts.hooks, "exec", "x", nil, "Shell command to run for each event, can be repeated")
+	cmd.Flags().StringSliceVar(&opts.on, "on", nil, "Only report these events: created, completed, updated, deleted")
+	cmd.Flags().BoolVar(&opts.once, "once", false, "Poll once and exit")
+	_ = cmd.RegisterFlagCompletionFunc("on", watch.EventTypeCompletionFunc)
+
+	return cmd
+}
@@ -0,0 +1,142 @@
+package agenda
+
+import (
+	"cmp"
+	"github.com/sho0pi/tickli/internal/export"
+	"github.com/sho0pi/tickli/internal/timeline"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/types/task"
+	"slices"
+	"time"
+)
+
+// Kind tells whether an entry is the start or the due date of its task
+type Kind string
+
+const (
+	KindDue   Kind = "due"
+	KindStart Kind = "start"
+)
+
+// Entry is a task on one day of the agenda. Time is the local time of the date, or the local
+// midnight of its day for all-day tasks, whose date is taken in the time zone of the task.
+type Entry struct {
+	Task      types.Task `json:"task"`
+	ProjectID string     `json:"projectId"`
+	Project   string     `json:"project"`
+	Kind      Kind       `json:"kind"`
+	Time      time.Time  `json:"time"`
+	IsAllDay  bool       `json:"isAllDay"`
+	Overdue   bool       `json:"overdue"`
+}
+
+// Day returns the local midnight starting the day of the entry
+func (e Entry) Day() time.Time {
+	return timeline.Day(e.Time)
+}
+
+// TaskTime returns the time of the entry in the time zone of its task, and whether that zone is
+// another offset than the local one
+func (e Entry) TaskTime() (time.Time, bool) {
+	ts := types.TickTickTime(e.Time)
+	local := export.LocalTime(ts, e.Task.TimeZone)
+	_, taskOffset := local.Zone()
+	_, localOffset := e.Time.Zone()
+	return local, !e.IsAllDay && taskOffset != localOffset
+}
+
+type Day struct {
+	Date    time.Time `json:"date"`
+	Entries []Entry   `json:"entries"`
+}
+
+// Agenda is the open tasks due before today, then the tasks of each day from today on
+type Agenda struct {
+	Overdue []Entry `json:"overdue"`
+	Days    []Day   `json:"days"`
+}
+
+// Entries lists the due date of every open task, and its start date when it falls on another day
+func Entries(projects []types.ProjectData, now time.Time) []Entry {
+	var entries []Entry
+	for _, data := range projects {
+		for _, t := range data.Tasks {
+			if t.Status == task.StatusComplete {
+				continue
+			}
+			entry := Entry{Task: t, ProjectID: data.Project.ID, Project: data.Project.Name, IsAllDay: t.IsAllDay}
+
+			var due time.Time
+			if !t.DueDate.IsZero() {
+				due = entryTime(t, t.DueDate)
+				end := due
+				if t.IsAllDay {
+					// All-day spans end at the midnight after their last day
+					if !t.StartDate.IsZero() && due.After(entryTime(t, t.StartDate)) {
+						due = due.AddDate(0, 0, -1)
+					}
+					end = due.AddDate(0, 0, 1)
+				}
+				e := entry
+				e.Kind, e.Time, e.Overdue = KindDue, due, end.Before(now)
+				entries = append(entries, e)
+			}
+			if !t.StartDate.IsZero() {
+				start := entryTime(t, t.StartDate)
+				if due.IsZero() || !timeline.Day(start).Equal(timeline.Day(due)) {
+					entry.Kind, entry.Time = KindStart, start
+					entries = append(entries, entry)
+				}
+			}
+		}
+	}
+	Sort(entries)
+	return entries
+}
+
+// Build returns the agenda of the days from today, with the overdue tasks first
+func Build(projects []types.ProjectData, days int, now time.Time) Agenda {
+	today := timeline.Day(now)
+	agenda := Agenda{Days: make([]Day, days)}
+	for i := range agenda.Days {
+		agenda.Days[i].Date = today.AddDate(0, 0, i)
+	}
+	for _, e := range Entries(projects, now) {
+		offset := timeline.DaysBetween(today, e.Day())
+		switch {
+		case e.Overdue && e.Kind == KindDue && offset < 0:
+			agenda.Overdue = append(agenda.Overdue, e)
+		case offset >= 0 && offset < days:
+			agenda.Days[offset].Entries = append(agenda.Days[offset].Entries, e)
+		}
+	}
+	return agenda
+}
+
+// Sort orders entries by day, all-day entries first, then by time and priority
+func Sort(entries []Entry) {
+	slices.SortStableFunc(entries, func(a, b Entry) int {
+		if c := a.Day().Compare(b.Day()); c != 0 {
+			return c
+		}
+		if a.IsAllDay != b.IsAllDay {
+			if a.IsAllDay {
+				return -1
+			}
+			return 1
+		}
+		if c := a.Time.Compare(b.Time); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.Task.Priority, a.Task.Priority)
+	})
+}
+
+// entryTime returns the local time of a timed date, or the local midnight of the calendar date of an
+// all-day one in the task time zone
+func entryTime(t types.Task, ts types.TickTickTime) time.Time {
+	if t.IsAllDay {
+		return timeline.Day(export.LocalTime(ts, t.TimeZone))
+	}
+	return time.Time(ts).Local()
+}
@@ -0,0 +1,47 @@
+package agenda
+
+import (
+	"github.com/sho0pi/tickli/internal/timeline"
+	"github.com/sho0pi/tickli/internal/types"
+	"time"
+)
+
+// Month counts the agenda entries of each day of a month
+type Month struct {
+	First time.Time
+	// Counts and Overdue are indexed by the day of the month minus one
+	Counts  []int
+	Overdue []int
+}
+
+// Days returns the number of days in the month
+func (m Month) Days() int {
+	return len(m.Counts)
+}
+
+// Total returns the number of entries in the month
+func (m Month) Total() int {
+	total := 0
+	for _, n := range m.Counts {
+		total += n
+	}
+	return total
+}
+
+// BuildMonth counts the entries on each day of the month holding the given day
+func BuildMonth(projects []types.ProjectData, month time.Time, now time.Time) Month {
+	first := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.Local)
+	days := timeline.DaysBetween(first, first.AddDate(0, 1, 0))
+	m := Month{First: first, Counts: make([]int, days), Overdue: make([]int, days)}
+	for _, e := range Entries(projects, now) {
+		offset := timeline.DaysBetween(first, e.Day())
+		if offset < 0 || offset >= days {
+			continue
+		}
+		m.Counts[offset]++
+		if e.Overdue {
+			m.Overdue[offset]++
+		}
+	}
+	return m
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"fmt"
+	"github.com/go-resty/resty/v2"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/sho0pi/tickli/internal/cache"
+	"github.com/sho0pi/tickli/internal/types"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrOffline is returned by requests made in offline mode
+var ErrOffline = errors.New("offline mode, the request needs the network")
+
+// CacheOptions controls how the client reads through the cache
+type CacheOptions struct {
+	// TTL is how long cached responses are used before fetching them again, 0 disables caching
+	TTL time.Duration
+	// Refresh ignores cached responses, fetching and caching them again
+	Refresh bool
+	// Offline never uses the network, cached responses are used however old they are
+	Offline bool
+	// Live fails requests that can't reach the server instead of using stale cached responses
+	Live bool
+}
+
+const projectsKey = "projects"
+
+func projectKey(projectID string) string {
+	// Inbox tasks carry the real inbox ID, while the inbox is fetched with its alias
+	if projectID == "" || strings.HasPrefix(projectID, types.InboxProject.ID) {
+		projectID = types.InboxProject.ID
+	}
+	return "project-" + projectID
+}
+
+// UseCache makes the client read projects and tasks through the cache, mutations invalidating the entries they change
+func (c *Client) UseCache(store *cache.Cache, opts CacheOptions) {
+	c.cache = store
+	c.cacheOpts = opts
+	c.http.OnBeforeRequest(func(_ *resty.Client, _ *resty.Request) error {
+		if c.cacheOpts.Offline {
+			return ErrOffline
+		}
+		return nil
+	})
+}
+
+// WithRefresh returns a client ignoring fresh cached responses, for commands that need the current server state
+func (c Client) WithRefresh() *Client {
+	c.cacheOpts.Refresh = !c.cacheOpts.Offline
+	return &c
+}
+
+// WithLive returns a client for commands that must act on the current server state: cached responses
+// are never used, even when the network is unreachable, and changes that fail to reach the server
+// fail rather than being queued
+func (c Client) WithLive() *Client {
+	c.cacheOpts.Refresh, c.cacheOpts.Live = true, true
+	c.journal = nil
+	return &c
+}
+
+// cached returns the value cached under key while it is fresh, fetching and caching it otherwise.
+// When the network is unreachable the last cached value is used, however old it is.
+func cached[T any](c *Client, key string, fetch func() (T, error)) (T, error) {
+	if c.cache == nil || c.cacheOpts.TTL <= 0 && !c.cacheOpts.Offline {
+		return fetch()
+	}
+
+	var v T
+	if !c.cacheOpts.Refresh {
+		age, ok := c.cache.Get(key, &v)
+		if ok && (c.cacheOpts.Offline || age < c.cacheOpts.TTL) {
+			return v, nil
+		}
+	}
+	if c.cacheOpts.Offline {
+		return v, fmt.Errorf("%w: %s is not cached", ErrOffline, key)
+	}
+
+	fetched, err := fetch()
+	if err != nil {
+		var stale T
+		if IsNetworkError(err) && !c.cacheOpts.Live {
+			if age, ok := c.cache.Get(key, &stale); ok {
+				log.Warn().Err(err).Str("age", age.Round(time.Second).String()).Msg("Network unreachable, using cached data")
+				return stale, nil
+			}
+		}
+		return fetched, err
+	}
+	if err := c.cache.Set(key, fetched); err != nil {
+		log.Debug().Err(err).Msg("Failed to cache response")
+	}
+	return fetched, nil
+}
+
+// invalidate drops the cached projects and the data of the given projects
+func (c *Client) invalidate(projects bool, projectIDs ...string) {
+	if c.cache == nil {
+		return
+	}
+	var keys []string
+	if projects {
+		keys = append(keys, projectsKey)
+	}
+	for _, id := range projectIDs {
+		keys = append(keys, projectKey(id))
+	}
+	c.cache.Delete(keys...)
+}
+
+// cachedTask looks a task up in the cached project data, used by GetTask in offline mode
+func (c *Client) cachedTask(projectID, taskID string) (*types.Task, bool) {
+	if c.cache == nil {
+		return nil, false
+	}
+	var data types.ProjectData
+	if _, ok := c.cache.Get(projectKey(projectID), &data); !ok {
+		return nil, false
+	}
+	for _, t := range data.Tasks {
+		if t.ID == taskID {
+			return &t, true
+		}
+	}
+	return nil, false
+}
+
+// IsNetworkError reports whether the request failed to reach the server, rather than being rejected by it
+func IsNetworkError(err error) bool {
+	if errors.Is(err, ErrOffline) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
@@ -5,6 +5,10 @@ import (
 	"fmt"
 	"github.com/go-resty/resty/v2"
 	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/cache"
+	"github.com/sho0pi/tickli/internal/history"
+	"github.com/sho0pi/tickli/internal/journal"
+	"github.com/sho0pi/tickli/internal/trash"
 	"github.com/sho0pi/tickli/internal/types"
 )
 
@@ -17,7 +21,12 @@ const (
 )
 
 type Client struct {
-	http *resty.Client
+	http      *resty.Client
+	cache     *cache.Cache
+	cacheOpts CacheOptions
+	journal   *journal.Journal
+	history   *history.Log
+	trash     *trash.Trash
 }
 
 func NewClient(token string) *Client {
@@ -61,6 +70,10 @@ func GetAccessToken(clientID, clientSecret, code string) (string, error) {
 }
 
 func (c *Client) ListProjects() ([]types.Project, error) {
+	return cached(c, projectsKey, c.listProjects)
+}
+
+func (c *Client) listProjects() ([]types.Project, error) {
 	var projects []types.Project
 	resp, err := c.http.R().
 		SetResult(&projects).
@@ -103,6 +116,24 @@ func (c *Client) GetProject(id string) (types.Project, error) {
 }
 
 func (c *Client) GetTask(projectID string, taskID string) (*types.Task, error) {
+	if journal.IsLocalID(taskID) {
+		if c.journal != nil {
+			if task, ok := c.journal.Task(taskID); ok {
+				return task, nil
+			}
+		}
+		return nil, localTaskError(taskID)
+	}
+	task, err := c.fetchTask(projectID, taskID)
+	if err != nil && IsNetworkError(err) && !c.cacheOpts.Live {
+		if cached, ok := c.cachedTask(projectID, taskID); ok {
+			return cached, nil
+		}
+	}
+	return task, err
+}
+
+func (c *Client) fetchTask(projectID string, taskID string) (*types.Task, error) {
 	var task types.Task
 	resp, err := c.http.R().
 		SetResult(&task).
@@ -119,25 +150,20 @@ func (c *Client) GetTask(projectID string, taskID string) (*types.Task, error) {
 }
 
 func (c *Client) ListTasks(projectID string) ([]types.Task, error) {
-	var projectData struct {
-		Tasks []types.Task `json:"tasks"`
-	}
-	resp, err := c.http.R().
-		SetResult(&projectData).
-		Get(fmt.Sprintf("/project/%s/data", projectID))
-
+	projectData, err := c.GetProjectWithTasks(projectID)
 	if err != nil {
-		return nil, errors.Wrap(err, "listing tasks")
-	}
-
-	if resp.IsError() {
-		return nil, fmt.Errorf("failed to list tasks: %s", resp.String())
+		return nil, err
 	}
-
 	return projectData.Tasks, nil
 }
 
 func (c *Client) GetProjectWithTasks(projectID string) (*types.ProjectData, error) {
+	return cached(c, projectKey(projectID), func() (*types.ProjectData, error) {
+		return c.getProjectWithTasks(projectID)
+	})
+}
+
+func (c *Client) getProjectWithTasks(projectID string) (*types.ProjectData, error) {
 	var projectData types.ProjectData
 	resp, err := c.http.R().
 		SetResult(&projectData).
@@ -164,11 +190,16 @@ func (c *Client) CreateTask(task *types.Task) (*types.Task, error) {
 		Post("/task")
 
 	if err != nil {
+		if queued, qerr := c.queueTask(err, journal.OpCreate, task); queued != nil {
+			return queued, qerr
+		}
 		return nil, errors.Wrap(err, "creating task")
 	}
 	if resp.IsError() {
 		return nil, fmt.Errorf("failed to create task: %s", resp.String())
 	}
+	c.invalidate(false, task.ProjectID)
+	c.record(history.Entry{Op: history.OpTaskCreate, Task: cloneTask(task)})
 
 	return task, nil
 }
@@ -177,6 +208,13 @@ func (c *Client) UpdateTask(task *types.Task) (*types.Task, error) {
 	if task == nil {
 		return nil, errors.New("task cannot be nil")
 	}
+	if journal.IsLocalID(task.ID) {
+		if c.journal == nil {
+			return nil, localTaskError(task.ID)
+		}
+		return c.queueTask(nil, journal.OpUpdate, task)
+	}
+	before := c.taskBefore(task.ProjectID, task.ID)
 
 	resp, err := c.http.R().
 		SetBody(task).
@@ -184,16 +222,24 @@ func (c *Client) UpdateTask(task *types.Task) (*types.Task, error) {
 		Post(fmt.Sprintf("/task/%s", task.ID))
 
 	if err != nil {
+		if queued, qerr := c.queueTask(err, journal.OpUpdate, task); queued != nil {
+			return queued, qerr
+		}
 		return nil, errors.Wrap(err, "updating task")
 	}
 	if resp.IsError() {
 		return nil, fmt.Errorf("failed to update task: %s", resp.String())
 	}
+	c.invalidate(false, task.ProjectID)
+	if before != nil {
+		c.record(history.Entry{Op: history.OpTaskUpdate, Task: before})
+	}
 
 	return task, nil
 }
 
 func (c *Client) UpdateProject(project types.Project) (types.Project, error) {
+	before := c.projectBefore(project.ID)
 	resp, err := c.http.R().
 		SetBody(project).
 		SetResult(project).
@@ -205,34 +251,71 @@ func (c *Client) UpdateProject(project types.Project) (types.Project, error) {
 	if resp.IsError() {
 		return types.NullProject, fmt.Errorf("failed to update project: %s", resp.String())
 	}
+	c.invalidate(true, project.ID)
+	if before != nil {
+		c.record(history.Entry{Op: history.OpProjectUpdate, Project: before})
+	}
 
 	return project, nil
 }
 
 func (c *Client) DeleteTask(projectID, taskID string) error {
+	if journal.IsLocalID(taskID) {
+		if c.journal == nil {
+			return localTaskError(taskID)
+		}
+		return c.queueChange(nil, journal.OpDelete, projectID, taskID)
+	}
+	before := c.taskBefore(projectID, taskID)
+	if err := c.putInTrash(before, nil); err != nil {
+		return err
+	}
 	resp, err := c.http.R().
 		Delete(fmt.Sprintf("/project/%s/task/%s", projectID, taskID))
 
 	if err != nil {
+		if qerr := c.queueChange(err, journal.OpDelete, projectID, taskID); qerr == ErrQueued {
+			return qerr
+		}
+		c.takeFromTrash(taskID)
 		return errors.Wrap(err, "deleting task")
 	}
 	if resp.IsError() {
+		c.takeFromTrash(taskID)
 		return fmt.Errorf("failed to delete task: %s", resp.String())
 	}
+	c.invalidate(false, projectID)
+	if before != nil {
+		c.record(history.Entry{Op: history.OpTaskDelete, Task: before})
+	}
 
 	return nil
 }
 
 func (c *Client) CompleteTask(projectID, taskID string) error {
+	if journal.IsLocalID(taskID) {
+		if c.journal == nil {
+			return localTaskError(taskID)
+		}
+		return c.queueChange(nil, journal.OpComplete, projectID, taskID)
+	}
+	before := c.taskBefore(projectID, taskID)
 	resp, err := c.http.R().
 		Post(fmt.Sprintf("/project/%s/task/%s/complete", projectID, taskID))
 
 	if err != nil {
+		if qerr := c.queueChange(err, journal.OpComplete, projectID, taskID); qerr == ErrQueued {
+			return qerr
+		}
 		return errors.Wrap(err, "completing task")
 	}
 	if resp.IsError() {
 		return fmt.Errorf("failed to complete task: %s", resp.String())
 	}
+	c.invalidate(false, projectID)
+	if before != nil {
+		c.record(history.Entry{Op: history.OpTaskComplete, Task: before})
+	}
 
 	return nil
 }
@@ -253,20 +336,33 @@ func (c *Client) CreateProject(project *types.Project) (*types.Project, error) {
 	if resp.IsError() {
 		return nil, fmt.Errorf("failed to create project: %s", resp.String())
 	}
+	c.invalidate(true)
+	created := *project
+	c.record(history.Entry{Op: history.OpProjectCreate, Project: &created})
 
 	return project, nil
 }
 
 func (c *Client) DeleteProject(projectID string) error {
+	before := c.projectDataBefore(projectID)
+	if err := c.putInTrash(nil, before); err != nil {
+		return err
+	}
 	resp, err := c.http.R().
 		Delete(fmt.Sprintf("/project/%s", projectID))
 
 	if err != nil {
+		c.takeFromTrash(projectID)
 		return errors.Wrap(err, "deleting project")
 	}
 	if resp.IsError() {
+		c.takeFromTrash(projectID)
 		return fmt.Errorf("failed to delete project: %s", resp.String())
 	}
+	c.invalidate(true, projectID)
+	if before != nil {
+		c.record(history.Entry{Op: history.OpProjectDelete, Project: &before.Project, Tasks: before.Tasks})
+	}
 
 	return nil
 }
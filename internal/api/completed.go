@@ -0,0 +1,33 @@
+package api
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/types"
+	"time"
+)
+
+// ListCompletedTasks returns the tasks of the projects completed between from and to. Project data
+// only holds open tasks, completed ones are listed through their own endpoint.
+func (c *Client) ListCompletedTasks(projectIDs []string, from, to time.Time) ([]types.Task, error) {
+	body := struct {
+		ProjectIDs []string           `json:"projectIds"`
+		StartDate  types.TickTickTime `json:"startDate"`
+		EndDate    types.TickTickTime `json:"endDate"`
+	}{projectIDs, types.TickTickTime(from), types.TickTickTime(to)}
+
+	var tasks []types.Task
+	resp, err := c.http.R().
+		SetBody(body).
+		SetResult(&tasks).
+		Post("/task/completed")
+
+	if err != nil {
+		return nil, errors.Wrap(err, "listing completed tasks")
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to list completed tasks: %s", resp.String())
+	}
+
+	return tasks, nil
+}
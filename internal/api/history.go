@@ -0,0 +1,243 @@
+package api
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/sho0pi/tickli/internal/history"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/types/task"
+	"slices"
+)
+
+// UseHistory records the mutations reaching the server in the history, with the state of the task
+// or project before the change, so they can be undone. Changes queued offline aren't recorded.
+func (c *Client) UseHistory(h *history.Log) {
+	c.history = h
+}
+
+// untracked returns a client that neither records its mutations nor trashes what it deletes, for
+// the steps of a recorded mutation and for undoing entries
+func (c Client) untracked() *Client {
+	c.history = nil
+	c.trash = nil
+	return &c
+}
+
+func (c *Client) record(entry history.Entry) {
+	if c.history == nil {
+		return
+	}
+	if err := c.history.Append(entry); err != nil {
+		log.Warn().Err(err).Msg("Failed to record the change in the history")
+	}
+}
+
+// taskBefore fetches the server copy of a task about to change, or the cached copy when the server
+// is unreachable, nil when neither is found
+func (c *Client) taskBefore(projectID, taskID string) *types.Task {
+	if c.history == nil && c.trash == nil {
+		return nil
+	}
+	t, err := c.fetchTask(projectID, taskID)
+	if err != nil && IsNetworkError(err) {
+		if cached, ok := c.cachedTask(projectID, taskID); ok {
+			return cached
+		}
+	}
+	if err != nil || t.ID == "" {
+		log.Debug().Err(err).Str("task", taskID).Msg("Failed to get the task before changing it")
+		return nil
+	}
+	return t
+}
+
+// projectBefore fetches the server copy of a project about to change, nil when it can't be fetched
+func (c *Client) projectBefore(projectID string) *types.Project {
+	if c.history == nil && c.trash == nil {
+		return nil
+	}
+	p, err := c.GetProject(projectID)
+	if err != nil {
+		log.Debug().Err(err).Str("project", projectID).Msg("Failed to get the project before changing it")
+		return nil
+	}
+	return &p
+}
+
+// projectDataBefore fetches a project about to be deleted with its tasks, nil when it can't be fetched
+func (c *Client) projectDataBefore(projectID string) *types.ProjectData {
+	p := c.projectBefore(projectID)
+	if p == nil {
+		return nil
+	}
+	data, err := c.getProjectWithTasks(projectID)
+	if err != nil {
+		log.Debug().Err(err).Str("project", projectID).Msg("Failed to get the project before changing it")
+		return nil
+	}
+	data.Project = *p
+	return data
+}
+
+func cloneTask(t *types.Task) *types.Task {
+	clone := *t
+	clone.Items = slices.Clone(t.Items)
+	clone.Reminders = slices.Clone(t.Reminders)
+	clone.Tags = slices.Clone(t.Tags)
+	return &clone
+}
+
+// UndoResult tells what undoing a history entry did
+type UndoResult struct {
+	Message string
+	// Replaced maps the IDs of recreated tasks and projects to their new IDs
+	Replaced map[string]string
+}
+
+// Undo reverts a history entry. The tasks and projects it names are looked up in ids first, the
+// new IDs of those recreated by undoing later entries. Recreated tasks and projects are taken out
+// of the trash, so they can't be restored a second time.
+func (c *Client) Undo(entry history.Entry, ids map[string]string) (UndoResult, error) {
+	tracked := c
+	c = c.untracked()
+	result := UndoResult{Replaced: make(map[string]string)}
+	done := func(format string, args ...any) (UndoResult, error) {
+		result.Message = fmt.Sprintf(format, args...)
+		return result, nil
+	}
+	// A task recreated twice maps to the ID it got the second time
+	mapped := func(id string) string {
+		for range len(ids) {
+			newID, ok := ids[id]
+			if !ok {
+				break
+			}
+			id = newID
+		}
+		return id
+	}
+
+	switch entry.Op {
+	case history.OpTaskCreate:
+		if err := c.DeleteTask(mapped(entry.Task.ProjectID), mapped(entry.Task.ID)); err != nil {
+			return result, err
+		}
+		return done("deleted the task")
+
+	case history.OpTaskUpdate, history.OpTaskComplete:
+		// Restoring the whole task also brings back the dates of a completed repeating task
+		before := cloneTask(entry.Task)
+		before.ID, before.ProjectID = mapped(before.ID), mapped(before.ProjectID)
+		if _, err := c.UpdateTask(before); err != nil {
+			return result, err
+		}
+		if entry.Op == history.OpTaskComplete {
+			return done("reactivated the task")
+		}
+		return done("restored the task")
+
+	case history.OpTaskDelete:
+		created, err := c.recreateTask(*entry.Task, mapped(entry.Task.ProjectID), entry.Task.ColumnID)
+		if err != nil {
+			return result, err
+		}
+		result.Replaced[entry.Task.ID] = created.ID
+		tracked.takeFromTrash(entry.Task.ID)
+		return done("recreated the task as %s", created.ID)
+
+	case history.OpTaskMove:
+		id := entry.Task.ID
+		if entry.NewID != "" {
+			id = entry.NewID
+		}
+		t, err := c.fetchTask(mapped(entry.ToProjectID), mapped(id))
+		if err != nil {
+			return result, errors.Wrap(err, "failed to get task")
+		}
+		moved, err := c.moveOrCopyTask(*t, mapped(entry.Task.ProjectID))
+		if err != nil {
+			return result, err
+		}
+		if entry.Task.ColumnID != "" && moved.ColumnID != entry.Task.ColumnID {
+			moved.ColumnID = entry.Task.ColumnID
+			if moved, err = c.UpdateTask(moved); err != nil {
+				return result, errors.Wrap(err, "task moved back but its column could not be restored")
+			}
+		}
+		if moved.ID != entry.Task.ID {
+			result.Replaced[entry.Task.ID] = moved.ID
+			return done("moved the task back as %s", moved.ID)
+		}
+		return done("moved the task back")
+
+	case history.OpProjectCreate:
+		projectID := mapped(entry.Project.ID)
+		data, err := c.getProjectWithTasks(projectID)
+		if err != nil {
+			return result, errors.Wrap(err, "failed to get project tasks")
+		}
+		if len(data.Tasks) > 0 {
+			return result, fmt.Errorf("the project has %d tasks now, delete it with 'tickli project delete' instead", len(data.Tasks))
+		}
+		if err := c.DeleteProject(projectID); err != nil {
+			return result, err
+		}
+		return done("deleted the project")
+
+	case history.OpProjectUpdate:
+		before := *entry.Project
+		before.ID = mapped(before.ID)
+		if _, err := c.UpdateProject(before); err != nil {
+			return result, err
+		}
+		return done("restored the project")
+
+	case history.OpProjectDelete:
+		project := *entry.Project
+		project.ID = ""
+		created, err := c.CreateProject(&project)
+		if err != nil {
+			return result, err
+		}
+		result.Replaced[entry.Project.ID] = created.ID
+		tracked.takeFromTrash(entry.Project.ID)
+
+		// Columns can't be created through the API, the tasks lose theirs. A task failing to be
+		// recreated is reported without failing the undo, which would recreate the project again.
+		recreated := 0
+		for _, t := range entry.Tasks {
+			copied, err := c.recreateTask(t, created.ID, "")
+			if err != nil {
+				log.Warn().Err(err).Str("task", t.ID).Str("title", t.Title).Msg("Failed to recreate task")
+				continue
+			}
+			result.Replaced[t.ID] = copied.ID
+			recreated++
+		}
+		return done("recreated the project as %s with %d of %d tasks", created.ID, recreated, len(entry.Tasks))
+	}
+	return result, fmt.Errorf("unknown history operation %q", entry.Op)
+}
+
+// recreateTask creates a copy of a deleted task in the project, completing it if it was completed
+func (c *Client) recreateTask(t types.Task, projectID, columnID string) (*types.Task, error) {
+	copied := *cloneTask(&t)
+	copied.ID = ""
+	copied.ProjectID = projectID
+	copied.ColumnID = columnID
+	for i := range copied.Items {
+		copied.Items[i].ID = ""
+	}
+
+	created, err := c.CreateTask(&copied)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to recreate task")
+	}
+	if t.Status == task.StatusComplete {
+		if err := c.CompleteTask(created.ProjectID, created.ID); err != nil {
+			return created, errors.Wrap(err, "task recreated but could not be completed")
+		}
+	}
+	return created, nil
+}
@@ -0,0 +1,272 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/journal"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/types/task"
+	"slices"
+)
+
+// ErrQueued is returned by task mutations recorded in the journal because the server was unreachable
+var ErrQueued = errors.New("offline, the change was queued, run 'tickli sync' when back online")
+
+// UseJournal queues task mutations in the journal when the server is unreachable,
+// and for tasks created offline, instead of failing
+func (c *Client) UseJournal(j *journal.Journal) {
+	c.journal = j
+}
+
+// queueTask journals a create or update that failed to reach the server
+func (c *Client) queueTask(err error, op journal.Op, task *types.Task) (*types.Task, error) {
+	entry, err := c.enqueue(err, op, task.ProjectID, task.ID, task)
+	if err != nil {
+		return nil, err
+	}
+	*task = *entry.Task
+	return task, ErrQueued
+}
+
+// queueChange journals a complete or delete that failed to reach the server
+func (c *Client) queueChange(err error, op journal.Op, projectID, taskID string) error {
+	if _, err := c.enqueue(err, op, projectID, taskID, nil); err != nil {
+		return err
+	}
+	return ErrQueued
+}
+
+// enqueue appends the mutation to the journal, returning the request error back when it isn't
+// a network failure. A nil request error means the task only exists in the journal.
+func (c *Client) enqueue(err error, op journal.Op, projectID, taskID string, task *types.Task) (journal.Entry, error) {
+	if c.journal == nil || err != nil && !IsNetworkError(err) {
+		return journal.Entry{}, err
+	}
+	entry := journal.Entry{Op: op, ProjectID: projectID, TaskID: taskID, Task: task}
+	if !journal.IsLocalID(taskID) && op != journal.OpCreate {
+		// The cached copy predates the changes already queued for the task, which the server will
+		// have by the time this one is sent
+		if queued, ok := c.journal.Task(taskID); ok {
+			entry.Base = queued
+		} else {
+			entry.Base, _ = c.cachedTask(projectID, taskID)
+		}
+	}
+	entry, err = c.journal.Append(entry)
+	if err != nil {
+		return entry, errors.Wrap(err, "queueing change")
+	}
+	return entry, nil
+}
+
+// localTaskError is returned for tasks created offline when no journal is used
+func localTaskError(taskID string) error {
+	return fmt.Errorf("task %s was created offline, run 'tickli sync' first", taskID)
+}
+
+type SyncOptions struct {
+	// Force applies changes even when the server copy of the task changed meanwhile
+	Force bool
+	// Discard drops the conflicting and failing changes from the journal instead of keeping them
+	Discard bool
+	DryRun  bool
+}
+
+type SyncResult struct {
+	Entry journal.Entry
+	// TaskID is the server ID of the task, the real ID of tasks created offline
+	TaskID   string
+	Conflict bool
+	// Kept reports whether the entry stays in the journal for a later sync
+	Kept bool
+	Err  error
+}
+
+// Sync replays the journal in order, returning the number of entries left in it. Tasks created
+// offline get their server ID, which replaces the local ID in later entries. Changes to tasks
+// modified on the server since they were queued are conflicts, kept in the journal unless forced
+// or discarded. Replay stops at the first network failure, keeping the rest for the next sync.
+func (c *Client) Sync(j *journal.Journal, opts SyncOptions, report func(SyncResult)) (int, error) {
+	entries, err := j.Entries()
+	if err != nil {
+		return 0, err
+	}
+	if opts.DryRun {
+		// Later changes of a task are based on the task as the earlier ones leave it, so they are
+		// checked against that state as the replay would, rather than the unchanged server copy
+		states := make(map[string]*types.Task)
+		for _, entry := range entries {
+			result := SyncResult{Entry: entry, TaskID: entry.TaskID, Kept: true}
+			current, known := states[entry.TaskID]
+			if !opts.Force && entry.Base != nil {
+				if !known {
+					current, result.Err = c.serverTask(entry)
+					known = result.Err == nil
+				}
+				if known {
+					result.Conflict, result.Err = baseConflict(current, entry.Base)
+				}
+			}
+			if result.Err == nil {
+				if next, ok := simulate(entry, current, known); ok {
+					states[entry.TaskID] = next
+				}
+			}
+			report(result)
+		}
+		return len(entries), nil
+	}
+
+	ids := make(map[string]string)
+	var kept []journal.Entry
+	save := func(rest []journal.Entry) error {
+		remaining := append(slices.Clone(kept), rest...)
+		for i := range remaining {
+			mapLocalID(&remaining[i], ids)
+		}
+		return j.Replace(remaining)
+	}
+
+	for i, entry := range entries {
+		mapLocalID(&entry, ids)
+		result := SyncResult{Entry: entry, TaskID: entry.TaskID}
+
+		switch {
+		case entry.Op != journal.OpCreate && journal.IsLocalID(entry.TaskID):
+			result.Err = fmt.Errorf("task %s was not created", entry.TaskID)
+		case !opts.Force && entry.Base != nil:
+			result.Conflict, result.Err = c.checkConflict(entry)
+		}
+		if result.Err == nil {
+			result.TaskID, result.Err = c.apply(entry)
+			if result.Err == nil && entry.Op == journal.OpCreate {
+				ids[entry.TaskID] = result.TaskID
+			}
+		}
+		if result.Err != nil && IsNetworkError(result.Err) {
+			result.Kept = true
+			report(result)
+			return len(kept) + len(entries) - i, save(entries[i:])
+		}
+		if result.Err != nil && !opts.Discard {
+			result.Kept = true
+			kept = append(kept, entry)
+		}
+		report(result)
+		if err := save(entries[i+1:]); err != nil {
+			return 0, err
+		}
+	}
+	return len(kept), nil
+}
+
+func (c *Client) apply(entry journal.Entry) (string, error) {
+	switch entry.Op {
+	case journal.OpCreate:
+		task := *entry.Task
+		task.ID = ""
+		created, err := c.CreateTask(&task)
+		if err != nil {
+			return "", err
+		}
+		return created.ID, nil
+	case journal.OpUpdate:
+		task := *entry.Task
+		_, err := c.UpdateTask(&task)
+		return entry.TaskID, err
+	case journal.OpComplete:
+		return entry.TaskID, c.CompleteTask(entry.ProjectID, entry.TaskID)
+	case journal.OpDelete:
+		return entry.TaskID, c.DeleteTask(entry.ProjectID, entry.TaskID)
+	default:
+		return entry.TaskID, fmt.Errorf("unknown journal operation %q", entry.Op)
+	}
+}
+
+// checkConflict compares the server copy of the task with the copy the change was based on,
+// reporting whether they conflict and why
+func (c *Client) checkConflict(entry journal.Entry) (bool, error) {
+	current, err := c.serverTask(entry)
+	if err != nil {
+		return false, err
+	}
+	return baseConflict(current, entry.Base)
+}
+
+// serverTask fetches the server copy of the task of the entry, nil when it doesn't exist anymore.
+// Only network failures are returned.
+func (c *Client) serverTask(entry journal.Entry) (*types.Task, error) {
+	current, err := c.fetchTask(entry.ProjectID, entry.TaskID)
+	if err != nil && IsNetworkError(err) {
+		return nil, err
+	}
+	if err != nil || current.ID == "" {
+		return nil, nil
+	}
+	return current, nil
+}
+
+// baseConflict reports whether the current copy of a task, nil when deleted, conflicts with the copy
+// a change was based on, and why
+func baseConflict(current, base *types.Task) (bool, error) {
+	if current == nil {
+		return true, errors.New("the task no longer exists on the server")
+	}
+	if !sameTask(current, base) {
+		return true, errors.New("the task changed on the server since the change was queued")
+	}
+	return false, nil
+}
+
+// simulate returns the task as the entry leaves it, nil when deleted, given the task before it when
+// known. It reports false when the resulting task isn't known.
+func simulate(entry journal.Entry, current *types.Task, known bool) (*types.Task, bool) {
+	switch entry.Op {
+	case journal.OpUpdate:
+		return entry.Task, true
+	case journal.OpDelete:
+		return nil, true
+	case journal.OpComplete:
+		if !known || current == nil {
+			return current, known
+		}
+		completed := *current
+		completed.Status = task.StatusComplete
+		return &completed, true
+	default:
+		return nil, false
+	}
+}
+
+func mapLocalID(entry *journal.Entry, ids map[string]string) {
+	id, ok := ids[entry.TaskID]
+	if !ok {
+		return
+	}
+	entry.TaskID = id
+	if entry.Task != nil {
+		task := *entry.Task
+		task.ID = id
+		entry.Task = &task
+	}
+}
+
+// sameTask compares the user editable fields of two copies of a task
+func sameTask(a, b *types.Task) bool {
+	// The server returns empty lists where queued copies may hold none
+	orEmpty := func(s []string) []string {
+		if s == nil {
+			return []string{}
+		}
+		return s
+	}
+	fingerprint := func(t *types.Task) string {
+		data, _ := json.Marshal([]any{
+			t.Title, t.Content, t.Desc, t.Status, t.Priority, orEmpty(t.Tags), t.IsAllDay,
+			t.StartDate, t.DueDate, t.TimeZone, t.RepeatFlag, orEmpty(t.Reminders), len(t.Items),
+		})
+		return string(data)
+	}
+	return fingerprint(a) == fingerprint(b)
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/history"
+	"github.com/sho0pi/tickli/internal/journal"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/types/task"
+	"net/http"
+	"slices"
+)
+
+// errMoveUnsupported is returned when the server has no endpoint to move tasks
+var errMoveUnsupported = errors.New("moving tasks is not supported by the server")
+
+// MoveTask moves a task to another project, keeping its ID through the move endpoint of the API.
+// When the server doesn't support it, the task is copied to the project and the original deleted,
+// the copy getting a new ID and leaving the kanban column, which belongs to the old project.
+func (c *Client) MoveTask(t types.Task, toProjectID string) (*types.Task, error) {
+	if t.ProjectID == toProjectID {
+		return &t, nil
+	}
+	if journal.IsLocalID(t.ID) {
+		return nil, localTaskError(t.ID)
+	}
+
+	moved, err := c.moveOrCopyTask(t, toProjectID)
+	if err != nil {
+		return moved, err
+	}
+	c.record(history.Entry{Op: history.OpTaskMove, Task: cloneTask(&t), ToProjectID: toProjectID, NewID: moved.ID})
+	return moved, nil
+}
+
+func (c *Client) moveOrCopyTask(t types.Task, toProjectID string) (*types.Task, error) {
+	err := c.moveTask(t.ProjectID, toProjectID, t.ID)
+	switch {
+	case err == nil:
+		moved, err := c.fetchTask(toProjectID, t.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "task moved but could not be fetched")
+		}
+		return moved, nil
+	case errors.Is(err, errMoveUnsupported):
+		// The copy and deletion are recorded as the move, not on their own
+		return c.untracked().copyTask(t, toProjectID)
+	default:
+		return nil, err
+	}
+}
+
+func (c *Client) moveTask(fromProjectID, toProjectID, taskID string) error {
+	body := []struct {
+		FromProjectID string `json:"fromProjectId"`
+		ToProjectID   string `json:"toProjectId"`
+		TaskID        string `json:"taskId"`
+	}{{fromProjectID, toProjectID, taskID}}
+
+	resp, err := c.http.R().
+		SetBody(body).
+		Post("/task/move")
+
+	if err != nil {
+		return errors.Wrap(err, "moving task")
+	}
+	switch {
+	case resp.StatusCode() == http.StatusNotFound || resp.StatusCode() == http.StatusMethodNotAllowed:
+		return errMoveUnsupported
+	case resp.IsError():
+		return fmt.Errorf("failed to move task: %s", resp.String())
+	}
+	c.invalidate(false, fromProjectID, toProjectID)
+
+	return nil
+}
+
+// copyTask moves a task by creating a copy in the project and deleting the original. The copy is
+// deleted again when the move can't be finished, leaving the original untouched.
+func (c *Client) copyTask(t types.Task, toProjectID string) (*types.Task, error) {
+	moved := t
+	moved.ID = ""
+	moved.ProjectID = toProjectID
+	moved.ColumnID = ""
+	moved.Items = slices.Clone(t.Items)
+	for i := range moved.Items {
+		moved.Items[i].ID = ""
+	}
+
+	created, err := c.CreateTask(&moved)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to copy task")
+	}
+	rollback := func(cause error, msg string) (*types.Task, error) {
+		if err := c.DeleteTask(created.ProjectID, created.ID); err != nil {
+			return created, errors.Wrapf(cause, "%s, and the copy %s could not be removed", msg, created.ID)
+		}
+		return nil, errors.Wrap(cause, msg)
+	}
+
+	if t.Status == task.StatusComplete {
+		if err := c.CompleteTask(created.ProjectID, created.ID); err != nil {
+			return rollback(err, "failed to complete the copied task")
+		}
+	}
+	if err := c.DeleteTask(t.ProjectID, t.ID); err != nil {
+		return rollback(err, "failed to delete the original task")
+	}
+	return created, nil
+}
@@ -2,7 +2,11 @@ package api
 
 import (
 	"fmt"
+	"github.com/pkg/errors"
 	"github.com/sho0pi/tickli/internal/config"
+	"github.com/sho0pi/tickli/internal/types"
+	"strings"
+	"sync"
 )
 
 func GetClient() (*Client, error) {
@@ -13,3 +17,109 @@ func GetClient() (*Client, error) {
 
 	return NewClient(token), nil
 }
+
+// maxConcurrentRequests bounds the number of project data requests in flight
+const maxConcurrentRequests = 4
+
+// ListProjectsData fetches the tasks and columns of the given projects concurrently.
+// Projects are given by ID or name, when none are given all open projects including the
+// inbox are fetched.
+func (c *Client) ListProjectsData(projectIDs ...string) ([]types.ProjectData, error) {
+	projects, err := c.ListProjects()
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []types.Project
+	if len(projectIDs) == 0 {
+		for _, p := range projects {
+			if !p.Closed {
+				selected = append(selected, p)
+			}
+		}
+	} else {
+		for _, id := range projectIDs {
+			p, ok := FindProject(projects, id)
+			if !ok {
+				return nil, fmt.Errorf("project not found: %s", id)
+			}
+			selected = append(selected, p)
+		}
+	}
+
+	data := make([]types.ProjectData, len(selected))
+	errs := make([]error, len(selected))
+	sem := make(chan struct{}, maxConcurrentRequests)
+	var wg sync.WaitGroup
+	for i, p := range selected {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			projectData, err := c.GetProjectWithTasks(p.ID)
+			if err != nil {
+				errs[i] = errors.Wrap(err, fmt.Sprintf("project %s", p.Name))
+				return
+			}
+			// The inbox data doesn't include the project itself
+			projectData.Project = p
+			data[i] = *projectData
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// ResolveProjectIDs returns the IDs of the projects given by ID or name, in order
+func (c *Client) ResolveProjectIDs(namesOrIDs ...string) ([]string, error) {
+	if len(namesOrIDs) == 0 {
+		return nil, nil
+	}
+	projects, err := c.ListProjects()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(namesOrIDs))
+	for _, nameOrID := range namesOrIDs {
+		p, ok := FindProject(projects, nameOrID)
+		if !ok {
+			return nil, fmt.Errorf("project not found: %s", nameOrID)
+		}
+		ids = append(ids, p.ID)
+	}
+	return ids, nil
+}
+
+// FindProject finds a project by ID, then by name ignoring case, then treating dashes and underscores
+// in names as spaces, so names written as todo.txt words still match
+func FindProject(projects []types.Project, nameOrID string) (types.Project, bool) {
+	for _, p := range projects {
+		if p.ID == nameOrID {
+			return p, true
+		}
+	}
+	for _, p := range projects {
+		if strings.EqualFold(strings.TrimSpace(p.Name), strings.TrimSpace(nameOrID)) {
+			return p, true
+		}
+	}
+	normalize := func(s string) string {
+		return strings.ToLower(strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+			return r == ' ' || r == '-' || r == '_'
+		}), " "))
+	}
+	for _, p := range projects {
+		if normalize(p.Name) == normalize(nameOrID) {
+			return p, true
+		}
+	}
+	return types.NullProject, false
+}
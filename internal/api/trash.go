@@ -0,0 +1,101 @@
+package api
+
+import (
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/sho0pi/tickli/internal/history"
+	"github.com/sho0pi/tickli/internal/trash"
+	"github.com/sho0pi/tickli/internal/types"
+)
+
+// UseTrash keeps the tasks and projects about to be deleted in the trash, projects with their tasks
+// and columns, so they can be restored. The Open API has no access to the TickTick trash.
+func (c *Client) UseTrash(t *trash.Trash) {
+	c.trash = t
+}
+
+// putInTrash stores the task or project about to be deleted, failing the deletion when it can't be
+// stored. Nothing is stored when it couldn't be fetched.
+func (c *Client) putInTrash(task *types.Task, data *types.ProjectData) error {
+	if c.trash == nil {
+		return nil
+	}
+	var item trash.Item
+	switch {
+	case task != nil:
+		item = trash.TaskItem(*task)
+	case data != nil:
+		item = trash.ProjectItem(*data)
+	default:
+		log.Warn().Msg("Deleting without keeping a copy in the trash, it couldn't be fetched")
+		return nil
+	}
+	if err := c.trash.Put(item); err != nil {
+		return errors.Wrap(err, "failed to keep a copy in the trash, nothing was deleted")
+	}
+	return nil
+}
+
+// takeFromTrash drops the item of a deletion that failed or was undone
+func (c *Client) takeFromTrash(id string) {
+	if c.trash == nil {
+		return
+	}
+	if err := c.trash.Remove(id); err != nil {
+		log.Debug().Err(err).Str("id", id).Msg("Failed to remove the trash item")
+	}
+}
+
+// Restore recreates a task or project of the trash, tasks in toProjectID when it is set. Restored
+// tasks and projects get new IDs, projects lose their kanban columns which can't be created.
+func (c *Client) Restore(item trash.Item, toProjectID string) (UndoResult, error) {
+	switch item.Kind {
+	case trash.KindTask:
+		t := *cloneTask(item.Task)
+		if toProjectID != "" && toProjectID != t.ProjectID {
+			t.ProjectID = toProjectID
+			t.ColumnID = ""
+		}
+		result, err := c.Undo(history.Entry{Op: history.OpTaskDelete, Task: &t}, nil)
+		if err == nil {
+			c.markRestored(item, result.Replaced)
+		}
+		return result, err
+	case trash.KindProject:
+		result, err := c.Undo(history.Entry{
+			Op:      history.OpProjectDelete,
+			Project: &item.Project.Project,
+			Tasks:   item.Project.Tasks,
+		}, nil)
+		if err == nil {
+			c.markRestored(item, result.Replaced)
+		}
+		return result, err
+	}
+	return UndoResult{}, errors.Errorf("unknown trash item kind %q", item.Kind)
+}
+
+// markRestored marks the history entry of the deletion of a restored item undone, with the IDs
+// restoring it replaced, so undo doesn't recreate it a second time
+func (c *Client) markRestored(item trash.Item, replaced map[string]string) {
+	if c.history == nil {
+		return
+	}
+	entries, err := c.history.Entries()
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to read the history, undo may recreate the restored item again")
+		return
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		deleted := e.Op == history.OpTaskDelete && item.Kind == trash.KindTask && e.Task.ID == item.ID ||
+			e.Op == history.OpProjectDelete && item.Kind == trash.KindProject && e.Project.ID == item.ID
+		if e.Undone || !deleted {
+			continue
+		}
+		if err := c.history.MarkUndone(e.ID, replaced); err != nil {
+			log.Warn().Err(err).Msg("Failed to update the history, undo may recreate the restored item again")
+		}
+		return
+	}
+}
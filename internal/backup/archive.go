@@ -0,0 +1,132 @@
+package backup
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/config"
+	"github.com/sho0pi/tickli/internal/types"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// Version is the archive format version, bumped on incompatible changes
+const Version = 1
+
+// Archive is a snapshot of an account: every project with its tasks and columns, and the config
+type Archive struct {
+	Version   int                 `json:"version"`
+	CreatedAt time.Time           `json:"createdAt"`
+	Config    config.Config       `json:"config"`
+	Projects  []types.ProjectData `json:"projects"`
+}
+
+// Create snapshots every project of the account, closed ones included
+func Create(client *api.Client, cfg config.Config) (*Archive, error) {
+	projects, err := client.ListProjects()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list projects")
+	}
+	ids := make([]string, len(projects))
+	for i, p := range projects {
+		ids[i] = p.ID
+	}
+	data, err := client.ListProjectsData(ids...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch projects")
+	}
+	return &Archive{
+		Version:   Version,
+		CreatedAt: time.Now().UTC(),
+		Config:    cfg,
+		Projects:  data,
+	}, nil
+}
+
+// TaskCount returns the number of tasks in the archive
+func (a *Archive) TaskCount() int {
+	count := 0
+	for _, p := range a.Projects {
+		count += len(p.Tasks)
+	}
+	return count
+}
+
+// WriteFile writes the archive as JSON, gzip compressed when the path ends with .gz
+func WriteFile(path string, a *Archive) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to create archive")
+	}
+	if err := Write(f, a, strings.HasSuffix(path, ".gz")); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func Write(w io.Writer, a *Archive, compress bool) error {
+	if compress {
+		gz := gzip.NewWriter(w)
+		if err := writeJSON(gz, a); err != nil {
+			return err
+		}
+		return gz.Close()
+	}
+	return writeJSON(w, a)
+}
+
+func writeJSON(w io.Writer, a *Archive) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(a); err != nil {
+		return errors.Wrap(err, "failed to write archive")
+	}
+	return nil
+}
+
+// ReadFile reads an archive written by WriteFile, "-" reading from stdin
+func ReadFile(path string) (*Archive, error) {
+	if path == "-" {
+		return Read(os.Stdin)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open archive")
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// Read decodes an archive, detecting gzip compression from its content
+func Read(r io.Reader) (*Archive, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read archive")
+	}
+	if len(data) > 2 && data[0] == 0x1f && data[1] == 0x8b {
+		gz, err := gzip.NewReader(strings.NewReader(string(data)))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decompress archive")
+		}
+		if data, err = io.ReadAll(gz); err != nil {
+			return nil, errors.Wrap(err, "failed to decompress archive")
+		}
+	}
+
+	var a Archive
+	if err := json.Unmarshal(data, &a); err != nil {
+		return nil, errors.Wrap(err, "failed to parse archive")
+	}
+	if a.Version == 0 {
+		return nil, errors.New("not a tickli backup archive, missing version")
+	}
+	if a.Version > Version {
+		return nil, fmt.Errorf("archive version %d is newer than the supported version %d, upgrade tickli", a.Version, Version)
+	}
+	return &a, nil
+}
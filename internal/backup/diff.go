@@ -0,0 +1,208 @@
+package backup
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/render"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/types/task"
+	"time"
+)
+
+type ChangeKind string
+
+const (
+	ChangeAdded     ChangeKind = "added"
+	ChangeRemoved   ChangeKind = "removed"
+	ChangeCompleted ChangeKind = "completed"
+	ChangeModified  ChangeKind = "modified"
+)
+
+// FieldChange is the old and new value of a modified field, named like the output --fields
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+// Change is a project or task that differs between two snapshots
+type Change struct {
+	Kind ChangeKind `json:"kind"`
+	// Type is either "project" or "task"
+	Type    string        `json:"type"`
+	ID      string        `json:"id"`
+	Name    string        `json:"name"`
+	Project string        `json:"project,omitempty"`
+	Fields  []FieldChange `json:"fields,omitempty"`
+
+	projectID string
+}
+
+type Diff struct {
+	From    time.Time `json:"from"`
+	To      time.Time `json:"to"`
+	Changes []Change  `json:"changes"`
+}
+
+// Count returns the number of changes of the given kind
+func (d *Diff) Count(kind ChangeKind) int {
+	count := 0
+	for _, c := range d.Changes {
+		if c.Kind == kind {
+			count++
+		}
+	}
+	return count
+}
+
+// Compare lists the changes from snapshot a to snapshot b, projects first, in the order of the snapshots.
+// Tasks are matched by ID, a task missing from b is removed unless b has it as completed.
+func Compare(a, b *Archive) Diff {
+	diff := Diff{From: a.CreatedAt, To: b.CreatedAt}
+	before, after := indexProjects(a), indexProjects(b)
+
+	for _, p := range a.Projects {
+		if _, ok := after[p.Project.ID]; !ok {
+			diff.Changes = append(diff.Changes, projectChange(ChangeRemoved, p.Project, nil))
+		}
+	}
+	for _, p := range b.Projects {
+		old, ok := before[p.Project.ID]
+		if !ok {
+			diff.Changes = append(diff.Changes, projectChange(ChangeAdded, p.Project, nil))
+			continue
+		}
+		if fields := compareFields(render.ProjectFields, old.Project, p.Project); len(fields) > 0 {
+			diff.Changes = append(diff.Changes, projectChange(ChangeModified, p.Project, fields))
+		}
+	}
+
+	tasksBefore, tasksAfter := indexTasks(a), indexTasks(b)
+	names := projectNames(a, b)
+	for _, p := range a.Projects {
+		for _, t := range p.Tasks {
+			if _, ok := tasksAfter[t.ID]; !ok {
+				diff.Changes = append(diff.Changes, taskChange(ChangeRemoved, t, names, nil))
+			}
+		}
+	}
+	for _, p := range b.Projects {
+		for _, t := range p.Tasks {
+			old, ok := tasksBefore[t.ID]
+			switch {
+			case !ok:
+				diff.Changes = append(diff.Changes, taskChange(ChangeAdded, t, names, nil))
+			case old.Status != task.StatusComplete && t.Status == task.StatusComplete:
+				diff.Changes = append(diff.Changes, taskChange(ChangeCompleted, t, names, nil))
+			default:
+				if fields := compareFields(render.TaskFields, old, t); len(fields) > 0 {
+					diff.Changes = append(diff.Changes, taskChange(ChangeModified, t, names, fields))
+				}
+			}
+		}
+	}
+	return diff
+}
+
+// ResolveCompleted asks the server about removed tasks. Open tasks are the only ones listed in
+// snapshots, so a removed task may have been completed rather than deleted.
+func (d *Diff) ResolveCompleted(client *api.Client) {
+	for i, c := range d.Changes {
+		if c.Type != "task" || c.Kind != ChangeRemoved {
+			continue
+		}
+		t, err := client.GetTask(c.projectID, c.ID)
+		if err == nil && t.ID == c.ID && t.Status == task.StatusComplete {
+			d.Changes[i].Kind = ChangeCompleted
+		}
+	}
+}
+
+func projectChange(kind ChangeKind, p types.Project, fields []FieldChange) Change {
+	return Change{Kind: kind, Type: "project", ID: p.ID, Name: p.Name, Fields: fields}
+}
+
+func taskChange(kind ChangeKind, t types.Task, projectNames map[string]string, fields []FieldChange) Change {
+	return Change{
+		Kind:      kind,
+		Type:      "task",
+		ID:        t.ID,
+		Name:      t.Title,
+		Project:   projectNames[t.ProjectID],
+		Fields:    fields,
+		projectID: t.ProjectID,
+	}
+}
+
+// compareFields returns the fields whose values differ, comparing their JSON representation
+func compareFields[T any](fields []render.Field[T], old, new T) []FieldChange {
+	var changes []FieldChange
+	for _, f := range fields {
+		o, n := f.Value(old), f.Value(new)
+		if !sameValue(o, n) {
+			changes = append(changes, FieldChange{Field: f.Name, Old: zeroTimeAsNil(o), New: zeroTimeAsNil(n)})
+		}
+	}
+	return changes
+}
+
+func sameValue(a, b any) bool {
+	if ta, ok := a.(types.TickTickTime); ok {
+		if tb, ok := b.(types.TickTickTime); ok {
+			return ta.Equal(tb)
+		}
+	}
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	// Nil and empty lists are the same value
+	if (bytes.Equal(ja, []byte("null")) || bytes.Equal(ja, []byte("[]"))) &&
+		(bytes.Equal(jb, []byte("null")) || bytes.Equal(jb, []byte("[]"))) {
+		return true
+	}
+	return bytes.Equal(ja, jb)
+}
+
+// zeroTimeAsNil makes unset dates null in the JSON output
+func zeroTimeAsNil(v any) any {
+	if t, ok := v.(types.TickTickTime); ok && t.IsZero() {
+		return nil
+	}
+	return v
+}
+
+func indexProjects(a *Archive) map[string]types.ProjectData {
+	index := make(map[string]types.ProjectData, len(a.Projects))
+	for _, p := range a.Projects {
+		index[p.Project.ID] = p
+	}
+	return index
+}
+
+func indexTasks(a *Archive) map[string]types.Task {
+	index := make(map[string]types.Task)
+	for _, p := range a.Projects {
+		for _, t := range p.Tasks {
+			index[t.ID] = t
+		}
+	}
+	return index
+}
+
+// projectNames maps project IDs of both snapshots to their latest name. Inbox tasks carry
+// the real inbox ID rather than the "inbox" alias of the project, so it is mapped too.
+func projectNames(snapshots ...*Archive) map[string]string {
+	names := make(map[string]string)
+	for _, a := range snapshots {
+		for _, p := range a.Projects {
+			names[p.Project.ID] = p.Project.Name
+			for _, t := range p.Tasks {
+				names[t.ProjectID] = p.Project.Name
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,280 @@
+package backup
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/spf13/cobra"
+	"slices"
+	"strings"
+)
+
+// Policy decides what happens to archived tasks and projects that already exist
+type Policy string
+
+const (
+	// PolicySkip leaves existing items untouched
+	PolicySkip Policy = "skip"
+	// PolicyOverwrite replaces existing items with their archived version
+	PolicyOverwrite Policy = "overwrite"
+	// PolicyDuplicate creates archived tasks again next to the existing ones
+	PolicyDuplicate Policy = "duplicate"
+)
+
+var PolicyCompletion = []cobra.Completion{
+	cobra.CompletionWithDesc(string(PolicySkip), "Keep existing tasks and projects"),
+	cobra.CompletionWithDesc(string(PolicyOverwrite), "Replace existing tasks and projects with the archived version"),
+	cobra.CompletionWithDesc(string(PolicyDuplicate), "Create archived tasks again next to the existing ones"),
+}
+
+var PolicyCompletionFunc = cobra.FixedCompletions(PolicyCompletion, cobra.ShellCompDirectiveNoFileComp)
+
+func (p *Policy) Set(value string) error {
+	switch Policy(value) {
+	case PolicySkip, PolicyOverwrite, PolicyDuplicate:
+		*p = Policy(value)
+	default:
+		return fmt.Errorf("invalid conflict policy: %s", value)
+	}
+	return nil
+}
+
+func (p Policy) String() string {
+	return string(p)
+}
+
+func (p *Policy) Type() string {
+	return "ConflictPolicy"
+}
+
+type RestoreOptions struct {
+	Policy Policy
+	// ProjectIDs restricts the restore to these archived projects, all projects when empty
+	ProjectIDs []string
+	DryRun     bool
+}
+
+type Action string
+
+const (
+	ActionCreated    Action = "created"
+	ActionUpdated    Action = "updated"
+	ActionDuplicated Action = "duplicated"
+	ActionSkipped    Action = "skipped"
+	ActionFailed     Action = "failed"
+)
+
+// Result is the outcome of restoring a project or a task, Task being nil for projects
+type Result struct {
+	Project types.Project
+	Task    *types.Task
+	Action  Action
+	Err     error
+}
+
+type Summary struct {
+	Created, Updated, Skipped, Failed int
+	// ProjectIDs maps archived project IDs to the IDs of the restored projects
+	ProjectIDs map[string]string
+}
+
+// Restore recreates the archived projects and tasks. Projects are matched by ID, then by name,
+// and created when missing. Tasks are matched by ID, then by title, within their project;
+// the policy decides what happens to the matched ones.
+func Restore(client *api.Client, a *Archive, opts RestoreOptions, report func(Result)) (Summary, error) {
+	summary := Summary{ProjectIDs: make(map[string]string)}
+	existing, err := client.ListProjects()
+	if err != nil {
+		return summary, errors.Wrap(err, "failed to list projects")
+	}
+
+	count := func(r Result) {
+		switch r.Action {
+		case ActionCreated, ActionDuplicated:
+			summary.Created++
+		case ActionUpdated:
+			summary.Updated++
+		case ActionSkipped:
+			summary.Skipped++
+		case ActionFailed:
+			summary.Failed++
+		}
+		report(r)
+	}
+
+	for _, data := range a.Projects {
+		if len(opts.ProjectIDs) > 0 && !slices.Contains(opts.ProjectIDs, data.Project.ID) {
+			continue
+		}
+		target, result := restoreProject(client, data.Project, existing, opts)
+		count(result)
+		if result.Action == ActionFailed {
+			continue
+		}
+		summary.ProjectIDs[data.Project.ID] = target.ID
+		if result.Action == ActionCreated {
+			existing = append(existing, target)
+		}
+
+		current := &types.ProjectData{Project: target}
+		if isExisting(target) {
+			if current, err = client.GetProjectWithTasks(target.ID); err != nil {
+				count(Result{Project: target, Action: ActionFailed, Err: errors.Wrap(err, "failed to get project tasks")})
+				continue
+			}
+		}
+		columns := columnMapping(data.Columns, current.Columns)
+		for _, t := range data.Tasks {
+			count(restoreTask(client, target, t, current.Tasks, columns, opts))
+		}
+	}
+	return summary, nil
+}
+
+func restoreProject(client *api.Client, archived types.Project, existing []types.Project, opts RestoreOptions) (types.Project, Result) {
+	result := Result{Project: archived}
+	if archived.ID == types.InboxProject.ID || strings.HasPrefix(archived.ID, types.InboxProject.ID) {
+		result.Project, result.Action = types.InboxProject, ActionSkipped
+		return types.InboxProject, result
+	}
+
+	current, ok := api.FindProject(existing, archived.ID)
+	if !ok {
+		current, ok = api.FindProject(existing, archived.Name)
+	}
+	if !ok {
+		result.Action = ActionCreated
+		if opts.DryRun {
+			p := archived
+			p.ID = "new:" + archived.ID
+			return p, result
+		}
+		created, err := client.CreateProject(&types.Project{
+			Name:     archived.Name,
+			Color:    archived.Color,
+			ViewMode: archived.ViewMode,
+			Kind:     archived.Kind,
+		})
+		if err != nil {
+			result.Action, result.Err = ActionFailed, errors.Wrap(err, "failed to create project")
+			return archived, result
+		}
+		result.Project = *created
+		return *created, result
+	}
+
+	result.Project = current
+	if opts.Policy != PolicyOverwrite || projectEqual(current, archived) {
+		result.Action = ActionSkipped
+		return current, result
+	}
+	result.Action = ActionUpdated
+	updated := current
+	updated.Name, updated.Color, updated.ViewMode = archived.Name, archived.Color, archived.ViewMode
+	if opts.DryRun {
+		return updated, result
+	}
+	updated, err := client.UpdateProject(updated)
+	if err != nil {
+		result.Action, result.Err = ActionFailed, errors.Wrap(err, "failed to update project")
+		return current, result
+	}
+	result.Project = updated
+	return updated, result
+}
+
+func restoreTask(client *api.Client, p types.Project, archived types.Task, current []types.Task, columns map[string]string, opts RestoreOptions) Result {
+	t := archived
+	t.Items = slices.Clone(archived.Items)
+	t.ProjectID = p.ID
+	t.ColumnID = columns[archived.ColumnID]
+	result := Result{Project: p, Task: &t}
+
+	match, ok := findTask(current, archived)
+	switch {
+	case !ok:
+		result.Action = ActionCreated
+	case opts.Policy == PolicySkip:
+		result.Action = ActionSkipped
+		return result
+	case opts.Policy == PolicyDuplicate:
+		result.Action = ActionDuplicated
+	case opts.Policy == PolicyOverwrite:
+		if taskEqual(match, t) {
+			result.Action = ActionSkipped
+			return result
+		}
+		result.Action = ActionUpdated
+	}
+	if opts.DryRun {
+		return result
+	}
+
+	var err error
+	var restored *types.Task
+	if result.Action == ActionUpdated {
+		t.ID = match.ID
+		restored, err = client.UpdateTask(&t)
+	} else {
+		t.ID = ""
+		for i := range t.Items {
+			t.Items[i].ID = ""
+		}
+		restored, err = client.CreateTask(&t)
+	}
+	if err != nil {
+		result.Action, result.Err = ActionFailed, err
+		return result
+	}
+	result.Task = restored
+	return result
+}
+
+// findTask matches an archived task by ID, then by title for archives restored into another account
+func findTask(tasks []types.Task, archived types.Task) (types.Task, bool) {
+	for _, t := range tasks {
+		if t.ID == archived.ID {
+			return t, true
+		}
+	}
+	for _, t := range tasks {
+		if t.Title == archived.Title {
+			return t, true
+		}
+	}
+	return types.Task{}, false
+}
+
+// columnMapping maps archived column IDs to the IDs of the current columns of the same name,
+// columns cannot be created through the API
+func columnMapping(archived, current []types.Column) map[string]string {
+	mapping := make(map[string]string)
+	for _, a := range archived {
+		for _, c := range current {
+			if c.ID == a.ID || strings.EqualFold(c.Name, a.Name) {
+				mapping[a.ID] = c.ID
+				break
+			}
+		}
+	}
+	return mapping
+}
+
+func isExisting(p types.Project) bool {
+	return !strings.HasPrefix(p.ID, "new:")
+}
+
+func projectEqual(a, b types.Project) bool {
+	return a.Name == b.Name && a.Color == b.Color && a.ViewMode == b.ViewMode
+}
+
+// taskEqual compares the fields a restore writes
+func taskEqual(a, b types.Task) bool {
+	return a.Title == b.Title && a.Content == b.Content && a.Desc == b.Desc &&
+		a.Priority == b.Priority && a.IsAllDay == b.IsAllDay && a.TimeZone == b.TimeZone &&
+		a.RepeatFlag == b.RepeatFlag && a.ColumnID == b.ColumnID &&
+		a.StartDate.Equal(b.StartDate) && a.DueDate.Equal(b.DueDate) &&
+		slices.Equal(a.Tags, b.Tags) &&
+		len(a.Items) == len(b.Items)
+}
@@ -0,0 +1,108 @@
+package bulk
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/types"
+	"io"
+	"strings"
+	"sync"
+)
+
+// DefaultWorkers is how many tasks are worked on at once, kept low to stay clear of rate limits
+const DefaultWorkers = 4
+
+const progressWidth = 30
+
+// Result is the outcome of the operation on one task
+type Result struct {
+	Task types.Task
+	Err  error
+}
+
+// Skipped is returned by operations for tasks left unchanged because they need no change
+type Skipped struct {
+	Reason string
+}
+
+func (s Skipped) Error() string {
+	return s.Reason
+}
+
+// Failed reports whether the operation failed, tasks skipped or queued while offline didn't fail
+func (r Result) Failed() bool {
+	var skip Skipped
+	if r.Err == nil || errors.As(r.Err, &skip) {
+		return false
+	}
+	return !errors.Is(r.Err, api.ErrQueued)
+}
+
+// Run applies fn to the tasks with a pool of workers, drawing a progress bar on progress when it is
+// not nil. Results are in the order of the tasks.
+func Run(tasks []types.Task, workers int, progress io.Writer, fn func(types.Task) error) []Result {
+	results := make([]Result, len(tasks))
+	bar := &progressBar{w: progress, total: len(tasks)}
+	bar.draw()
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range max(min(workers, len(tasks)), 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = Result{Task: tasks[i], Err: fn(tasks[i])}
+				bar.add(results[i].Failed())
+			}
+		}()
+	}
+	for i := range tasks {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	bar.finish()
+
+	return results
+}
+
+// progressBar redraws a single line with the share of finished tasks and the failures so far
+type progressBar struct {
+	w      io.Writer
+	total  int
+	done   int
+	failed int
+	mu     sync.Mutex
+}
+
+func (b *progressBar) add(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.done++
+	if failed {
+		b.failed++
+	}
+	b.draw()
+}
+
+func (b *progressBar) draw() {
+	if b.w == nil || b.total == 0 {
+		return
+	}
+	filled := progressWidth * b.done / b.total
+	line := fmt.Sprintf("\r[%s%s] %d/%d", strings.Repeat("█", filled), strings.Repeat("░", progressWidth-filled), b.done, b.total)
+	if b.failed > 0 {
+		line += fmt.Sprintf(", %d failed", b.failed)
+	}
+	fmt.Fprint(b.w, line)
+}
+
+func (b *progressBar) finish() {
+	if b.w == nil || b.total == 0 {
+		return
+	}
+	// Clear the bar, leaving the line to the summary
+	fmt.Fprint(b.w, "\r"+strings.Repeat(" ", progressWidth+40)+"\r")
+}
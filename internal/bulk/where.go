@@ -0,0 +1,174 @@
+package bulk
+
+import (
+	"fmt"
+	"github.com/sho0pi/tickli/internal/export"
+	"github.com/sho0pi/tickli/internal/timeline"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/types/task"
+	"github.com/sho0pi/tickli/internal/utils"
+	"slices"
+	"strings"
+	"time"
+)
+
+// operators are tried in order, two character operators first
+var operators = []string{">=", "<=", "!=", "=", "<", ">", "~"}
+
+// Condition is a single `<field><op><value>` test of a --where filter
+type Condition struct {
+	Field string
+	Op    string
+	Value string
+
+	priority task.Priority
+	day      time.Time
+}
+
+// Filter matches the tasks meeting all of its conditions
+type Filter []Condition
+
+// ParseWhere parses conditions like "tag=sprint", "priority>=medium", "due<today", "start=none",
+// "title~report" and "column=Done". Dates accept natural language.
+func ParseWhere(exprs []string) (Filter, error) {
+	filter := make(Filter, 0, len(exprs))
+	for _, expr := range exprs {
+		c, err := parseCondition(expr)
+		if err != nil {
+			return nil, err
+		}
+		filter = append(filter, c)
+	}
+	return filter, nil
+}
+
+func parseCondition(expr string) (Condition, error) {
+	idx := strings.IndexAny(expr, "<>=!~")
+	if idx <= 0 {
+		return Condition{}, fmt.Errorf("invalid condition %q, expected <field><op><value>", expr)
+	}
+	c := Condition{Field: strings.ToLower(strings.TrimSpace(expr[:idx]))}
+	for _, op := range operators {
+		if strings.HasPrefix(expr[idx:], op) {
+			c.Op = op
+			break
+		}
+	}
+	if c.Op == "" {
+		return Condition{}, fmt.Errorf("invalid operator in condition %q", expr)
+	}
+	c.Value = strings.TrimSpace(expr[idx+len(c.Op):])
+
+	switch c.Field {
+	case "tag", "title", "content", "column":
+		if c.Op != "=" && c.Op != "!=" && c.Op != "~" {
+			return Condition{}, fmt.Errorf("%s only supports =, != and ~, got %q", c.Field, expr)
+		}
+	case "priority":
+		if c.Op == "~" {
+			return Condition{}, fmt.Errorf("priority doesn't support ~, got %q", expr)
+		}
+		if err := c.priority.Set(c.Value); err != nil {
+			return Condition{}, err
+		}
+	case "due", "start":
+		if c.Op == "~" {
+			return Condition{}, fmt.Errorf("%s doesn't support ~, got %q", c.Field, expr)
+		}
+		if c.Value == "none" {
+			if c.Op != "=" && c.Op != "!=" {
+				return Condition{}, fmt.Errorf("%s=none and %s!=none are the only comparisons with none", c.Field, c.Field)
+			}
+			break
+		}
+		r, err := utils.ParseTimeExpression(c.Value)
+		if err != nil {
+			return Condition{}, fmt.Errorf("invalid date in condition %q: %w", expr, err)
+		}
+		c.day = timeline.Day(r.Start())
+	default:
+		return Condition{}, fmt.Errorf("unknown field %q, expected tag, title, content, column, priority, due or start", c.Field)
+	}
+	return c, nil
+}
+
+// Match reports whether the task meets every condition, columns being those of its project
+func (f Filter) Match(t types.Task, columns []types.Column) bool {
+	for _, c := range f {
+		if !c.match(t, columns) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c Condition) match(t types.Task, columns []types.Column) bool {
+	switch c.Field {
+	case "tag":
+		has := slices.ContainsFunc(t.Tags, func(tag string) bool {
+			if c.Op == "~" {
+				return strings.Contains(strings.ToLower(tag), strings.ToLower(c.Value))
+			}
+			return strings.EqualFold(tag, c.Value)
+		})
+		return has != (c.Op == "!=")
+	case "title":
+		return matchText(t.Title, c.Op, c.Value)
+	case "content":
+		return matchText(t.Content, c.Op, c.Value)
+	case "column":
+		column, ok := types.FindColumn(columns, c.Value)
+		in := ok && t.ColumnID == column.ID
+		if c.Op == "~" {
+			in = slices.ContainsFunc(columns, func(col types.Column) bool {
+				return col.ID == t.ColumnID && strings.Contains(strings.ToLower(col.Name), strings.ToLower(c.Value))
+			})
+		}
+		return in != (c.Op == "!=")
+	case "priority":
+		return compare(int(t.Priority), int(c.priority), c.Op)
+	case "due", "start":
+		ts := t.DueDate
+		if c.Field == "start" {
+			ts = t.StartDate
+		}
+		if c.Value == "none" {
+			return ts.IsZero() == (c.Op == "=")
+		}
+		if ts.IsZero() {
+			return false
+		}
+		day := timeline.Day(export.LocalTime(ts, t.TimeZone))
+		return compare(timeline.DaysBetween(c.day, day), 0, c.Op)
+	}
+	return false
+}
+
+func matchText(text, op, value string) bool {
+	switch op {
+	case "~":
+		return strings.Contains(strings.ToLower(text), strings.ToLower(value))
+	case "!=":
+		return !strings.EqualFold(text, value)
+	default:
+		return strings.EqualFold(text, value)
+	}
+}
+
+func compare(a, b int, op string) bool {
+	switch op {
+	case "=":
+		return a == b
+	case "!=":
+		return a != b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package cache
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Cache stores JSON values on disk, one file per key, along with the time they were stored
+type Cache struct {
+	dir string
+}
+
+type entry struct {
+	StoredAt time.Time       `json:"storedAt"`
+	Data     json.RawMessage `json:"data"`
+}
+
+func New(dir string) *Cache {
+	return &Cache{dir: dir}
+}
+
+// Dir returns the directory holding the cache files
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// Get decodes the value stored under key into v, reporting its age and whether it was found
+func (c *Cache) Get(key string, v any) (time.Duration, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return 0, false
+	}
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return 0, false
+	}
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return 0, false
+	}
+	return time.Since(e.StoredAt), true
+}
+
+// Set stores the value under key, replacing the file atomically so concurrent readers never see partial data
+func (c *Cache) Set(key string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrap(err, "encoding cache entry")
+	}
+	data, err = json.Marshal(entry{StoredAt: time.Now(), Data: data})
+	if err != nil {
+		return errors.Wrap(err, "encoding cache entry")
+	}
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return errors.Wrap(err, "creating cache directory")
+	}
+
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return errors.Wrap(err, "writing cache entry")
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return errors.Wrap(err, "writing cache entry")
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return errors.Wrap(err, "writing cache entry")
+	}
+	return os.Rename(tmp.Name(), c.path(key))
+}
+
+// Delete removes the given keys, missing keys are ignored
+func (c *Cache) Delete(keys ...string) {
+	for _, key := range keys {
+		_ = os.Remove(c.path(key))
+	}
+}
+
+// Clear removes every cached value
+func (c *Cache) Clear() error {
+	if err := os.RemoveAll(c.dir); err != nil {
+		return errors.Wrap(err, "clearing cache")
+	}
+	return nil
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, url.PathEscape(key)+".json")
+}
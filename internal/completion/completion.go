@@ -1,9 +1,11 @@
 package completion
 
 import (
+	"fmt"
 	"github.com/sho0pi/tickli/internal/api"
 	"github.com/sho0pi/tickli/internal/config"
 	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -17,8 +19,7 @@ func loadClient() (*api.Client, error) {
 		return nil, err
 	}
 
-	client := api.NewClient(token)
-	return client, nil
+	return utils.NewClient(token), nil
 }
 
 func ProjectIDs() cobra.CompletionFunc {
@@ -73,3 +74,56 @@ func ProjectCompletions(projects []types.Project, toComplete string) []cobra.Com
 	}
 	return completions
 }
+
+// ColumnNames completes the kanban column names of the project given with --project-id, or of the current project
+func ColumnNames() cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		client, err := loadClient()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		projectID, _ := cmd.Flags().GetString("project-id")
+		if projectID == "" {
+			cfg, err := config.Load()
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			projectID = cfg.DefaultProjectID
+		}
+
+		data, err := client.GetProjectWithTasks(projectID)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return ColumnCompletions(data), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func ColumnCompletions(data *types.ProjectData) []cobra.Completion {
+	var completions []cobra.Completion
+	for _, column := range data.Columns {
+		count := 0
+		for _, t := range data.Tasks {
+			if t.ColumnID == column.ID {
+				count++
+			}
+		}
+		completions = append(completions, cobra.CompletionWithDesc(column.Name, fmt.Sprintf("%d tasks", count)))
+	}
+	return completions
+}
+
+// TrashIDs completes the IDs of the tasks and projects in the local trash
+func TrashIDs() cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		items, err := utils.OpenTrash().List()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		var completions []cobra.Completion
+		for _, item := range items {
+			completions = append(completions, cobra.CompletionWithDesc(item.ID, fmt.Sprintf("%s %s", item.Kind, item.Title())))
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -10,15 +10,66 @@ import (
 )
 
 type Config struct {
-	DefaultProjectID    string `mapstructure:"default_project_id"`
-	DefaultProjectColor string `mapstructure:"default_project_color"`
+	DefaultProjectID    string       `mapstructure:"default_project_id" json:"default_project_id"`
+	DefaultProjectColor string       `mapstructure:"default_project_color" json:"default_project_color"`
+	Today               TodayConfig  `mapstructure:"today" json:"today"`
+	Trash               *TrashConfig `mapstructure:"trash" json:"trash,omitempty"`
 }
 
+// TodayConfig sets up the sections of the `tickli today` dashboard
+type TodayConfig struct {
+	// Sections are the sections shown, in order: overdue, today, soon, priority and completed
+	Sections []string `mapstructure:"sections" json:"sections"`
+	// SoonDays is how many days after today the soon section covers
+	SoonDays int `mapstructure:"soon_days" json:"soon_days"`
+	// Limit is the most tasks listed per section, 0 lists them all
+	Limit int `mapstructure:"limit" json:"limit"`
+}
+
+// TrashConfig sets how long deleted tasks and projects are kept in the local trash
+type TrashConfig struct {
+	// RetentionDays is how many days deleted items are kept, 0 keeps them until purged
+	RetentionDays int `mapstructure:"retention_days" json:"retention_days"`
+}
+
+// DefaultTodaySections are the sections of the dashboard when the config doesn't set them
+var DefaultTodaySections = []string{"overdue", "today", "soon", "priority", "completed"}
+
 var (
-	configPath = filepath.Join(xdg.ConfigHome, "tickli", "config.yaml")
-	tokenPath  = filepath.Join(xdg.DataHome, "tickli", "token")
+	configPath  = filepath.Join(xdg.ConfigHome, "tickli", "config.yaml")
+	tokenPath   = filepath.Join(xdg.DataHome, "tickli", "token")
+	cacheDir    = filepath.Join(xdg.CacheHome, "tickli")
+	stateDir    = filepath.Join(xdg.StateHome, "tickli")
+	journalPath = filepath.Join(stateDir, "journal.jsonl")
+	historyPath = filepath.Join(stateDir, "history.jsonl")
+	trashDir    = filepath.Join(stateDir, "trash")
 )
 
+// StateDir returns the directory of state kept between runs, like what was last synced
+func StateDir() string {
+	return stateDir
+}
+
+// JournalPath returns the file of task changes queued while offline
+func JournalPath() string {
+	return journalPath
+}
+
+// HistoryPath returns the file of changes made by tickli, kept to undo them
+func HistoryPath() string {
+	return historyPath
+}
+
+// TrashDir returns the directory of deleted tasks and projects kept to restore them
+func TrashDir() string {
+	return trashDir
+}
+
+// CacheDir returns the directory of cached API responses
+func CacheDir() string {
+	return cacheDir
+}
+
 func InitConfig() error {
 	viper.SetConfigFile(configPath)
 	viper.SetConfigType("yaml")
@@ -29,6 +80,10 @@ func InitConfig() error {
 
 	viper.SetDefault("default_project_id", "")
 	viper.SetDefault("default_project_color", "#FF1111")
+	viper.SetDefault("today.sections", DefaultTodaySections)
+	viper.SetDefault("today.soon_days", 3)
+	viper.SetDefault("today.limit", 10)
+	viper.SetDefault("trash.retention_days", 30)
 
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		if err := viper.SafeWriteConfigAs(configPath); err != nil {
@@ -56,9 +111,19 @@ func Load() (*Config, error) {
 	return &cfg, nil
 }
 
+// Save writes the config. Sections left empty or nil, as in configs backed up before they existed,
+// keep their current values.
 func Save(cfg *Config) error {
 	viper.Set("default_project_id", cfg.DefaultProjectID)
 	viper.Set("default_project_color", cfg.DefaultProjectColor)
+	if len(cfg.Today.Sections) > 0 {
+		viper.Set("today.sections", cfg.Today.Sections)
+		viper.Set("today.soon_days", cfg.Today.SoonDays)
+		viper.Set("today.limit", cfg.Today.Limit)
+	}
+	if cfg.Trash != nil {
+		viper.Set("trash.retention_days", cfg.Trash.RetentionDays)
+	}
 	return viper.WriteConfigAs(configPath)
 }
 
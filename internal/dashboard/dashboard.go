@@ -0,0 +1,129 @@
+package dashboard
+
+import (
+	"cmp"
+	"fmt"
+	"github.com/sho0pi/tickli/internal/agenda"
+	"github.com/sho0pi/tickli/internal/timeline"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/types/task"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Kind is a section of the dashboard
+type Kind string
+
+const (
+	KindOverdue   Kind = "overdue"
+	KindToday     Kind = "today"
+	KindSoon      Kind = "soon"
+	KindPriority  Kind = "priority"
+	KindCompleted Kind = "completed"
+)
+
+// ParseKinds checks the names of sections
+func ParseKinds(names []string) ([]Kind, error) {
+	kinds := make([]Kind, len(names))
+	for i, name := range names {
+		switch k := Kind(name); k {
+		case KindOverdue, KindToday, KindSoon, KindPriority, KindCompleted:
+			kinds[i] = k
+		default:
+			return nil, fmt.Errorf("invalid dashboard section: %s", name)
+		}
+	}
+	return kinds, nil
+}
+
+// Section is a titled list of tasks. Entries may be cut to a limit, Total counts them all.
+type Section struct {
+	Kind    Kind
+	Title   string
+	Entries []agenda.Entry
+	Total   int
+}
+
+type Options struct {
+	Sections []Kind
+	// SoonDays is how many days after today the soon section covers
+	SoonDays int
+	// Limit is the most entries kept per section, 0 keeps them all
+	Limit int
+	Now   time.Time
+}
+
+// Build fills the sections from the open tasks of the projects and the tasks completed today
+func Build(projects []types.ProjectData, completed []types.Task, opts Options) []Section {
+	today := timeline.Day(opts.Now)
+	var due []agenda.Entry
+	for _, e := range agenda.Entries(projects, opts.Now) {
+		if e.Kind == agenda.KindDue {
+			due = append(due, e)
+		}
+	}
+
+	sections := make([]Section, len(opts.Sections))
+	for i, kind := range opts.Sections {
+		s := Section{Kind: kind}
+		switch kind {
+		case KindOverdue:
+			s.Title = "Overdue"
+			s.Entries = filter(due, func(e agenda.Entry) bool { return e.Overdue && e.Day().Before(today) })
+		case KindToday:
+			s.Title = "Due today"
+			s.Entries = filter(due, func(e agenda.Entry) bool { return e.Day().Equal(today) })
+		case KindSoon:
+			s.Title = fmt.Sprintf("Due in the next %d days", opts.SoonDays)
+			s.Entries = filter(due, func(e agenda.Entry) bool {
+				days := timeline.DaysBetween(today, e.Day())
+				return days > 0 && days <= opts.SoonDays
+			})
+		case KindPriority:
+			s.Title = "High priority, no date"
+			for _, data := range projects {
+				for _, t := range data.Tasks {
+					if t.Priority == task.PriorityHigh && t.Status != task.StatusComplete && t.DueDate.IsZero() && t.StartDate.IsZero() {
+						s.Entries = append(s.Entries, agenda.Entry{Task: t, ProjectID: data.Project.ID, Project: data.Project.Name})
+					}
+				}
+			}
+		case KindCompleted:
+			s.Title = "Completed today"
+			names := make(map[string]string, len(projects))
+			for _, data := range projects {
+				names[data.Project.ID] = data.Project.Name
+			}
+			for _, t := range completed {
+				done := time.Time(t.CompletedTime).Local()
+				if t.CompletedTime.IsZero() || done.Before(today) {
+					continue
+				}
+				projectID := t.ProjectID
+				// Inbox tasks carry the real inbox ID rather than its alias
+				if strings.HasPrefix(projectID, types.InboxProject.ID) {
+					projectID = types.InboxProject.ID
+				}
+				s.Entries = append(s.Entries, agenda.Entry{Task: t, ProjectID: projectID, Project: names[projectID], Time: done})
+			}
+			slices.SortFunc(s.Entries, func(a, b agenda.Entry) int { return cmp.Compare(b.Time.Unix(), a.Time.Unix()) })
+		}
+		s.Total = len(s.Entries)
+		if opts.Limit > 0 && len(s.Entries) > opts.Limit {
+			s.Entries = s.Entries[:opts.Limit]
+		}
+		sections[i] = s
+	}
+	return sections
+}
+
+func filter(entries []agenda.Entry, keep func(agenda.Entry) bool) []agenda.Entry {
+	var kept []agenda.Entry
+	for _, e := range entries {
+		if keep(e) {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
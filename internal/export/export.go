@@ -0,0 +1,89 @@
+package export
+
+import (
+	"fmt"
+	"github.com/sho0pi/tickli/internal/types"
+	"io"
+	"slices"
+	"time"
+)
+
+// Group is a set of tasks sharing a kanban column, ColumnID and Name are empty for tasks outside any column
+type Group struct {
+	ColumnID string
+	Name     string
+	Tasks    []types.Task
+}
+
+// Project writes the project with its tasks in the given format
+func Project(w io.Writer, data *types.ProjectData, format Format) error {
+	switch format {
+	case FormatMarkdown:
+		return Markdown(w, data)
+	case FormatTodoTxt:
+		return TodoTxt(w, data)
+	case FormatOrg:
+		return Org(w, data)
+	default:
+		return fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+// GroupByColumn groups the tasks by their kanban column in column order
+func GroupByColumn(data *types.ProjectData) []Group {
+	columns := slices.Clone(data.Columns)
+	slices.SortStableFunc(columns, func(a, b types.Column) int {
+		return compareInt64(a.SortOrder, b.SortOrder)
+	})
+
+	tasks := slices.Clone(data.Tasks)
+	slices.SortStableFunc(tasks, func(a, b types.Task) int {
+		return compareInt64(a.SortOrder, b.SortOrder)
+	})
+
+	var groups []Group
+	grouped := make(map[string]bool)
+	for _, column := range columns {
+		group := Group{ColumnID: column.ID, Name: column.Name}
+		for _, t := range tasks {
+			if t.ColumnID == column.ID {
+				group.Tasks = append(group.Tasks, t)
+				grouped[t.ID] = true
+			}
+		}
+		groups = append(groups, group)
+	}
+
+	var rest []types.Task
+	for _, t := range tasks {
+		if !grouped[t.ID] {
+			rest = append(rest, t)
+		}
+	}
+	if len(rest) > 0 || len(groups) == 0 {
+		groups = append([]Group{{Tasks: rest}}, groups...)
+	}
+	return groups
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// LocalTime returns the time in the task time zone, falling back to the local time zone
+func LocalTime(t types.TickTickTime, timeZone string) time.Time {
+	loc := time.Local
+	if timeZone != "" {
+		if l, err := time.LoadLocation(timeZone); err == nil {
+			loc = l
+		}
+	}
+	return time.Time(t).In(loc)
+}
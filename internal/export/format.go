@@ -0,0 +1,43 @@
+package export
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+)
+
+// Format is a document format a project can be exported to
+type Format string
+
+const (
+	FormatMarkdown Format = "markdown"
+	FormatTodoTxt  Format = "todotxt"
+	FormatOrg      Format = "org"
+)
+
+var FormatCompletion = []cobra.Completion{
+	cobra.CompletionWithDesc(string(FormatMarkdown), "Markdown checklist"),
+	cobra.CompletionWithDesc(string(FormatTodoTxt), "todo.txt lines"),
+	cobra.CompletionWithDesc(string(FormatOrg), "Emacs Org mode outline"),
+}
+
+var FormatCompletionFunc = cobra.FixedCompletions(FormatCompletion, cobra.ShellCompDirectiveNoFileComp)
+
+func (f *Format) Set(value string) error {
+	switch Format(value) {
+	case FormatMarkdown, FormatTodoTxt, FormatOrg:
+		*f = Format(value)
+	case "md":
+		*f = FormatMarkdown
+	default:
+		return fmt.Errorf("invalid export format: %s", value)
+	}
+	return nil
+}
+
+func (f Format) String() string {
+	return string(f)
+}
+
+func (f *Format) Type() string {
+	return "ExportFormat"
+}
@@ -0,0 +1,246 @@
+package export
+
+import (
+	"fmt"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/types/task"
+	"github.com/spf13/cobra"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ICSComponent is the iCalendar component tasks are exported as
+type ICSComponent string
+
+const (
+	ICSEvent ICSComponent = "event"
+	ICSTodo  ICSComponent = "todo"
+)
+
+var ICSComponentCompletion = []cobra.Completion{
+	cobra.CompletionWithDesc(string(ICSEvent), "VEVENT entries, shown by every calendar app"),
+	cobra.CompletionWithDesc(string(ICSTodo), "VTODO entries, shown by apps supporting tasks"),
+}
+
+var ICSComponentCompletionFunc = cobra.FixedCompletions(ICSComponentCompletion, cobra.ShellCompDirectiveNoFileComp)
+
+func (c *ICSComponent) Set(value string) error {
+	switch ICSComponent(strings.ToLower(value)) {
+	case ICSEvent, "vevent":
+		*c = ICSEvent
+	case ICSTodo, "vtodo":
+		*c = ICSTodo
+	default:
+		return fmt.Errorf("invalid calendar component: %s", value)
+	}
+	return nil
+}
+
+func (c ICSComponent) String() string {
+	return string(c)
+}
+
+func (c *ICSComponent) Type() string {
+	return "ICSComponent"
+}
+
+type ICSOptions struct {
+	// Name is the calendar name shown by calendar apps
+	Name      string
+	Component ICSComponent
+	// Stamp is the DTSTAMP of every entry, the current time when zero
+	Stamp time.Time
+}
+
+const (
+	icsDateLayout     = "20060102"
+	icsDateTimeLayout = "20060102T150405"
+	ticktickTaskURL   = "https://ticktick.com/webapp/#p/%s/tasks/%s"
+)
+
+// ICS writes the dated tasks of the projects as an iCalendar feed, the task ID being the stable UID of its entry
+func ICS(w io.Writer, projects []types.ProjectData, opts ICSOptions) error {
+	stamp := opts.Stamp
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+	name := opts.Name
+	if name == "" {
+		name = "TickTick"
+	}
+
+	c := &icsWriter{}
+	c.line("BEGIN", "VCALENDAR")
+	c.line("VERSION", "2.0")
+	c.line("PRODID", "-//tickli//tickli//EN")
+	c.line("CALSCALE", "GREGORIAN")
+	c.line("METHOD", "PUBLISH")
+	c.line("X-WR-CALNAME", icsText(name))
+	for _, data := range projects {
+		for _, t := range data.Tasks {
+			if t.StartDate.IsZero() && t.DueDate.IsZero() {
+				continue
+			}
+			c.task(t, data.Project, opts.Component, stamp)
+		}
+	}
+	c.line("END", "VCALENDAR")
+
+	_, err := io.WriteString(w, c.sb.String())
+	return err
+}
+
+type icsWriter struct {
+	sb strings.Builder
+}
+
+func (c *icsWriter) task(t types.Task, p types.Project, component ICSComponent, stamp time.Time) {
+	name := "VEVENT"
+	if component == ICSTodo {
+		name = "VTODO"
+	}
+	c.line("BEGIN", name)
+	c.line("UID", t.ID+"@tickli")
+	c.line("DTSTAMP", stamp.UTC().Format(icsDateTimeLayout+"Z"))
+	c.line("SUMMARY", icsText(t.Title))
+	if description := icsDescription(t); description != "" {
+		c.line("DESCRIPTION", icsText(description))
+	}
+	if len(t.Tags) > 0 {
+		tags := make([]string, len(t.Tags))
+		for i, tag := range t.Tags {
+			tags[i] = icsText(tag)
+		}
+		c.line("CATEGORIES", strings.Join(tags, ","))
+	}
+	if p.Name != "" {
+		c.line("X-TICKLI-PROJECT", icsText(p.Name))
+	}
+	c.line("URL", fmt.Sprintf(ticktickTaskURL, t.ProjectID, t.ID))
+	if priority := icsPriority(t.Priority); priority > 0 {
+		c.line("PRIORITY", fmt.Sprint(priority))
+	}
+
+	start, due := t.StartDate, t.DueDate
+	if start.IsZero() {
+		start = due
+	}
+	hasStart := true
+	if component == ICSTodo {
+		// DUE is the due date itself, only event ends are exclusive, and has to come after DTSTART,
+		// so a start at the due time is left out. Repeating to-dos without a start of their own are
+		// then written with DUE only, which their recurrence is anchored on.
+		startsOnDue := !t.DueDate.IsZero() && !time.Time(due).After(time.Time(start))
+		hasStart = !t.StartDate.IsZero() && !startsOnDue
+		if hasStart {
+			c.time("DTSTART", start, t)
+		}
+		if !t.DueDate.IsZero() {
+			c.time("DUE", due, t)
+		}
+		if t.Status == task.StatusComplete {
+			c.line("STATUS", "COMPLETED")
+			if !t.CompletedTime.IsZero() {
+				c.line("COMPLETED", time.Time(t.CompletedTime).UTC().Format(icsDateTimeLayout+"Z"))
+			}
+		} else {
+			c.line("STATUS", "NEEDS-ACTION")
+		}
+	} else {
+		// Events have no status meaning done, completed tasks are left without one rather than
+		// CANCELLED, which would show them as called off
+		c.time("DTSTART", start, t)
+		switch {
+		case t.IsAllDay:
+			end := due
+			if end.IsZero() || time.Time(end).Before(time.Time(start)) {
+				end = start
+			}
+			// All-day events end exclusively on the following day
+			c.time("DTEND", types.TickTickTime(LocalTime(end, t.TimeZone).AddDate(0, 0, 1)), t)
+		case !t.StartDate.IsZero() && time.Time(due).After(time.Time(start)):
+			c.time("DTEND", due, t)
+		}
+	}
+
+	if strings.HasPrefix(t.RepeatFlag, "RRULE:") {
+		c.line("RRULE", strings.TrimPrefix(t.RepeatFlag, "RRULE:"))
+	}
+	for _, reminder := range t.Reminders {
+		if _, err := types.ReminderOffset(reminder); err != nil {
+			continue
+		}
+		c.line("BEGIN", "VALARM")
+		c.line("ACTION", "DISPLAY")
+		c.line("DESCRIPTION", icsText(t.Title))
+		if hasStart {
+			c.line("TRIGGER", types.ReminderTrigger(reminder))
+		} else {
+			// Relative triggers are anchored on DTSTART, without one the alarm is relative to DUE
+			c.line("TRIGGER;RELATED=END", types.ReminderTrigger(reminder))
+		}
+		c.line("END", "VALARM")
+	}
+	c.line("END", name)
+}
+
+// time writes a date in the task time zone for all-day tasks, and a UTC time otherwise. UTC times
+// need no VTIMEZONE component, which a TZID parameter would require.
+func (c *icsWriter) time(name string, ts types.TickTickTime, t types.Task) {
+	if t.IsAllDay {
+		c.line(name+";VALUE=DATE", LocalTime(ts, t.TimeZone).Format(icsDateLayout))
+		return
+	}
+	c.line(name, time.Time(ts).UTC().Format(icsDateTimeLayout+"Z"))
+}
+
+// line writes a content line folded at 75 octets as required by RFC 5545
+func (c *icsWriter) line(name, value string) {
+	l := name + ":" + value
+	limit := 75
+	for len(l) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(l[cut]) {
+			cut--
+		}
+		c.sb.WriteString(l[:cut] + "\r\n ")
+		l = l[cut:]
+		// Continuation lines start with a space
+		limit = 74
+	}
+	c.sb.WriteString(l + "\r\n")
+}
+
+func icsText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+func icsDescription(t types.Task) string {
+	lines := contentLines(t.Content)
+	for _, item := range t.Items {
+		lines = append(lines, fmt.Sprintf("- %s %s", markdownCheckbox(item.IsCompleted()), item.Title))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// icsPriority maps the priority onto the iCalendar scale where 1 is the highest and 0 undefined
+func icsPriority(p task.Priority) int {
+	switch p {
+	case task.PriorityHigh:
+		return 1
+	case task.PriorityMedium:
+		return 5
+	case task.PriorityLow:
+		return 9
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,87 @@
+package export
+
+import (
+	"fmt"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/types/task"
+	"io"
+	"strings"
+)
+
+// Markdown writes a GitHub flavoured checklist, dates and priorities use the Obsidian Tasks emoji conventions
+func Markdown(w io.Writer, data *types.ProjectData) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n", data.Project.Name)
+	for _, group := range GroupByColumn(data) {
+		if group.Name != "" {
+			fmt.Fprintf(&sb, "\n## %s\n", group.Name)
+		}
+		sb.WriteString("\n")
+		for _, t := range group.Tasks {
+			fmt.Fprintf(&sb, "- %s %s%s\n", markdownCheckbox(t.Status == task.StatusComplete), t.Title, markdownMeta(t))
+			for _, line := range contentLines(t.Content) {
+				fmt.Fprintf(&sb, "  %s\n", line)
+			}
+			for _, item := range t.Items {
+				fmt.Fprintf(&sb, "  - %s %s\n", markdownCheckbox(item.IsCompleted()), item.Title)
+			}
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func markdownCheckbox(done bool) string {
+	if done {
+		return "[x]"
+	}
+	return "[ ]"
+}
+
+// MarkdownPriority maps priorities to their Obsidian Tasks emoji
+var MarkdownPriority = map[task.Priority]string{
+	task.PriorityHigh:   "⏫",
+	task.PriorityMedium: "🔼",
+	task.PriorityLow:    "🔽",
+}
+
+// Obsidian Tasks emoji written before the dates of a task
+const (
+	MarkdownStart = "🛫"
+	MarkdownDue   = "📅"
+	MarkdownDone  = "✅"
+)
+
+// MarkdownDateLayout is the format of the dates following the emoji
+const MarkdownDateLayout = "2006-01-02"
+
+func markdownMeta(t types.Task) string {
+	var meta []string
+	if emoji, ok := MarkdownPriority[t.Priority]; ok {
+		meta = append(meta, emoji)
+	}
+	if !t.StartDate.IsZero() {
+		meta = append(meta, MarkdownStart+" "+LocalTime(t.StartDate, t.TimeZone).Format(MarkdownDateLayout))
+	}
+	if !t.DueDate.IsZero() {
+		meta = append(meta, MarkdownDue+" "+LocalTime(t.DueDate, t.TimeZone).Format(MarkdownDateLayout))
+	}
+	if !t.CompletedTime.IsZero() {
+		meta = append(meta, MarkdownDone+" "+LocalTime(t.CompletedTime, t.TimeZone).Format(MarkdownDateLayout))
+	}
+	for _, tag := range t.Tags {
+		meta = append(meta, "#"+strings.ReplaceAll(tag, " ", "-"))
+	}
+	if len(meta) == 0 {
+		return ""
+	}
+	return " " + strings.Join(meta, " ")
+}
+
+func contentLines(content string) []string {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return nil
+	}
+	return strings.Split(content, "\n")
+}
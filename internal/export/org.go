@@ -0,0 +1,90 @@
+package export
+
+import (
+	"fmt"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/types/task"
+	"io"
+	"strings"
+)
+
+// Org writes an Org mode outline with a TODO heading per task and checklist items as checkboxes
+func Org(w io.Writer, data *types.ProjectData) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "#+TITLE: %s\n", data.Project.Name)
+	for _, group := range GroupByColumn(data) {
+		level := "*"
+		if group.Name != "" {
+			fmt.Fprintf(&sb, "\n* %s\n", group.Name)
+			level = "**"
+		}
+		for _, t := range group.Tasks {
+			keyword := "TODO"
+			if t.Status == task.StatusComplete {
+				keyword = "DONE"
+			}
+			heading := []string{level, keyword}
+			if p := orgPriority(t.Priority); p != "" {
+				heading = append(heading, p)
+			}
+			heading = append(heading, t.Title)
+			if len(t.Tags) > 0 {
+				tags := make([]string, len(t.Tags))
+				for i, tag := range t.Tags {
+					tags[i] = strings.Join(strings.Fields(tag), "_")
+				}
+				heading = append(heading, ":"+strings.Join(tags, ":")+":")
+			}
+			fmt.Fprintf(&sb, "%s\n", strings.Join(heading, " "))
+
+			indent := strings.Repeat(" ", len(level)+1)
+			var planning []string
+			if !t.CompletedTime.IsZero() {
+				planning = append(planning, "CLOSED: "+orgTimestamp(t.CompletedTime, t, "[", "]"))
+			}
+			if !t.DueDate.IsZero() {
+				planning = append(planning, "DEADLINE: "+orgTimestamp(t.DueDate, t, "<", ">"))
+			}
+			if !t.StartDate.IsZero() {
+				planning = append(planning, "SCHEDULED: "+orgTimestamp(t.StartDate, t, "<", ">"))
+			}
+			if len(planning) > 0 {
+				fmt.Fprintf(&sb, "%s%s\n", indent, strings.Join(planning, " "))
+			}
+			fmt.Fprintf(&sb, "%s:PROPERTIES:\n%s:ID: %s\n%s:END:\n", indent, indent, t.ID, indent)
+			for _, line := range contentLines(t.Content) {
+				fmt.Fprintf(&sb, "%s%s\n", indent, line)
+			}
+			for _, item := range t.Items {
+				checkbox := "[ ]"
+				if item.IsCompleted() {
+					checkbox = "[X]"
+				}
+				fmt.Fprintf(&sb, "%s- %s %s\n", indent, checkbox, item.Title)
+			}
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func orgPriority(p task.Priority) string {
+	switch p {
+	case task.PriorityHigh:
+		return "[#A]"
+	case task.PriorityMedium:
+		return "[#B]"
+	case task.PriorityLow:
+		return "[#C]"
+	default:
+		return ""
+	}
+}
+
+func orgTimestamp(ts types.TickTickTime, t types.Task, open, close string) string {
+	layout := "2006-01-02 Mon"
+	if !t.IsAllDay {
+		layout += " 15:04"
+	}
+	return open + LocalTime(ts, t.TimeZone).Format(layout) + close
+}
@@ -0,0 +1,125 @@
+package export
+
+import (
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/types/task"
+	"io"
+	"strings"
+	"time"
+)
+
+// TaskwarriorTask is a task of the Taskwarrior JSON export format
+type TaskwarriorTask struct {
+	UUID        string                  `json:"uuid"`
+	Description string                  `json:"description"`
+	Status      string                  `json:"status"`
+	Entry       string                  `json:"entry,omitempty"`
+	Modified    string                  `json:"modified,omitempty"`
+	Scheduled   string                  `json:"scheduled,omitempty"`
+	Due         string                  `json:"due,omitempty"`
+	End         string                  `json:"end,omitempty"`
+	Project     string                  `json:"project,omitempty"`
+	Priority    string                  `json:"priority,omitempty"`
+	Tags        []string                `json:"tags,omitempty"`
+	Annotations []TaskwarriorAnnotation `json:"annotations,omitempty"`
+	// TickTickID is a user defined attribute holding the ID of the exported task
+	TickTickID string `json:"ticktickid,omitempty"`
+}
+
+type TaskwarriorAnnotation struct {
+	Entry       string `json:"entry"`
+	Description string `json:"description"`
+}
+
+// Taskwarrior status values
+const (
+	TaskwarriorPending   = "pending"
+	TaskwarriorCompleted = "completed"
+	TaskwarriorDeleted   = "deleted"
+	TaskwarriorWaiting   = "waiting"
+	TaskwarriorRecurring = "recurring"
+)
+
+// TaskwarriorTimeLayout is the UTC date format of Taskwarrior
+const TaskwarriorTimeLayout = "20060102T150405Z"
+
+// Taskwarrior writes the tasks of the projects as a Taskwarrior JSON export, ready for 'task import'.
+// Each content line becomes an annotation, and the UUID is derived from the task ID so
+// exporting again updates the imported tasks instead of duplicating them.
+func Taskwarrior(w io.Writer, projects []types.ProjectData, now time.Time) error {
+	tasks := []TaskwarriorTask{}
+	for _, data := range projects {
+		for _, t := range data.Tasks {
+			tasks = append(tasks, taskwarriorTask(t, data.Project, now))
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(tasks); err != nil {
+		return errors.Wrap(err, "failed to write taskwarrior tasks")
+	}
+	return nil
+}
+
+func taskwarriorTask(t types.Task, p types.Project, now time.Time) TaskwarriorTask {
+	entry := taskwarriorTime(types.TickTickTime(now))
+	tw := TaskwarriorTask{
+		UUID:        TaskwarriorUUID(t.ID),
+		Description: t.Title,
+		Status:      TaskwarriorPending,
+		Entry:       entry,
+		Modified:    entry,
+		Scheduled:   taskwarriorTime(t.StartDate),
+		Due:         taskwarriorTime(t.DueDate),
+		Project:     p.Name,
+		Priority:    TaskwarriorPriority(t.Priority),
+		Tags:        t.Tags,
+		TickTickID:  t.ID,
+	}
+	if t.Status == task.StatusComplete {
+		tw.Status = TaskwarriorCompleted
+		tw.End = taskwarriorTime(t.CompletedTime)
+		if tw.End == "" {
+			tw.End = entry
+		}
+	}
+	for _, line := range strings.Split(t.Content, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			tw.Annotations = append(tw.Annotations, TaskwarriorAnnotation{Entry: entry, Description: line})
+		}
+	}
+	return tw
+}
+
+func taskwarriorTime(t types.TickTickTime) string {
+	if t.IsZero() {
+		return ""
+	}
+	return time.Time(t).UTC().Format(TaskwarriorTimeLayout)
+}
+
+// TaskwarriorPriority maps a priority to Taskwarrior's H, M and L
+func TaskwarriorPriority(p task.Priority) string {
+	switch p {
+	case task.PriorityHigh:
+		return "H"
+	case task.PriorityMedium:
+		return "M"
+	case task.PriorityLow:
+		return "L"
+	default:
+		return ""
+	}
+}
+
+// TaskwarriorUUID derives a stable name based (version 5 style) UUID from a task ID
+func TaskwarriorUUID(taskID string) string {
+	sum := sha1.Sum([]byte("tickli:" + taskID))
+	sum[6] = sum[6]&0x0f | 0x50
+	sum[8] = sum[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
@@ -0,0 +1,78 @@
+package export
+
+import (
+	"fmt"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/types/task"
+	"io"
+	"strings"
+)
+
+// TodoTxt writes one todo.txt line per task, checklist items become lines linked to their task with a parent: key
+func TodoTxt(w io.Writer, data *types.ProjectData) error {
+	var sb strings.Builder
+	project := "+" + todoTxtWord(data.Project.Name)
+	for _, group := range GroupByColumn(data) {
+		for _, t := range group.Tasks {
+			var parts []string
+			if t.Status == task.StatusComplete {
+				parts = append(parts, "x")
+				if !t.CompletedTime.IsZero() {
+					parts = append(parts, LocalTime(t.CompletedTime, t.TimeZone).Format("2006-01-02"))
+				}
+			}
+			// Completed tasks keep their priority as a pri: key, the (A) form is only for open tasks
+			priority := todoTxtPriority(t.Priority)
+			if priority != "" && t.Status != task.StatusComplete {
+				parts = append(parts, "("+priority+")")
+			}
+			parts = append(parts, strings.Join(strings.Fields(t.Title), " "), project)
+			for _, tag := range t.Tags {
+				parts = append(parts, "@"+todoTxtWord(tag))
+			}
+			if group.Name != "" {
+				parts = append(parts, "column:"+todoTxtWord(group.Name))
+			}
+			if !t.StartDate.IsZero() {
+				parts = append(parts, "t:"+LocalTime(t.StartDate, t.TimeZone).Format("2006-01-02"))
+			}
+			if !t.DueDate.IsZero() {
+				parts = append(parts, "due:"+LocalTime(t.DueDate, t.TimeZone).Format("2006-01-02"))
+			}
+			if priority != "" && t.Status == task.StatusComplete {
+				parts = append(parts, "pri:"+priority)
+			}
+			parts = append(parts, "id:"+t.ID)
+			fmt.Fprintln(&sb, strings.Join(parts, " "))
+
+			for _, item := range t.Items {
+				line := fmt.Sprintf("%s %s parent:%s", strings.Join(strings.Fields(item.Title), " "), project, t.ID)
+				if item.IsCompleted() {
+					line = "x " + line
+				}
+				fmt.Fprintln(&sb, line)
+			}
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// todoTxtPriority returns the priority letter of a task, empty without a priority
+func todoTxtPriority(p task.Priority) string {
+	switch p {
+	case task.PriorityHigh:
+		return "A"
+	case task.PriorityMedium:
+		return "B"
+	case task.PriorityLow:
+		return "C"
+	default:
+		return ""
+	}
+}
+
+// todoTxtWord joins the words of s, todo.txt projects, contexts and values cannot contain spaces
+func todoTxtWord(s string) string {
+	return strings.Join(strings.Fields(s), "-")
+}
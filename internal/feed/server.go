@@ -0,0 +1,243 @@
+package feed
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/export"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/types/task"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Options struct {
+	ProjectIDs []string
+	Tag        string
+	Priority   task.Priority
+	ICS        bool
+	JSON       bool
+	// Interval between refreshes of the cached feeds, zero fetches on every request
+	Interval  time.Duration
+	Name      string
+	Component export.ICSComponent
+}
+
+// Server serves read-only iCalendar and JSON feeds of the selected projects
+type Server struct {
+	client *api.Client
+	opts   Options
+
+	mu       sync.RWMutex
+	snapshot *snapshot
+}
+
+type snapshot struct {
+	projects []types.ProjectData
+	hash     string
+	modified time.Time
+}
+
+func NewServer(client *api.Client, opts Options) *Server {
+	return &Server{client: client, opts: opts}
+}
+
+// Handler returns the routes of the enabled feeds
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", s.handleIndex)
+	if s.opts.ICS {
+		mux.HandleFunc("GET /calendar.ics", s.handleICS)
+		mux.HandleFunc("GET /projects/{id}/calendar.ics", s.handleICS)
+	}
+	if s.opts.JSON {
+		mux.HandleFunc("GET /tasks.json", s.handleJSON)
+		mux.HandleFunc("GET /projects/{id}/tasks.json", s.handleJSON)
+	}
+	return mux
+}
+
+// Run refreshes the cached feeds until the context is done. Failed refreshes are logged and retried
+// at the next interval, requests are answered with the last loaded feeds meanwhile.
+func (s *Server) Run(ctx context.Context) error {
+	if s.opts.Interval <= 0 {
+		<-ctx.Done()
+		return nil
+	}
+	ticker := time.NewTicker(s.opts.Interval)
+	defer ticker.Stop()
+	for {
+		if err := s.Refresh(); err != nil {
+			log.Warn().Err(err).Msg("failed to refresh feeds, serving cached data")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh fetches the selected projects, keeping the modification time when nothing changed
+func (s *Server) Refresh() error {
+	projects, err := s.client.ListProjectsData(s.opts.ProjectIDs...)
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch projects")
+	}
+	for i := range projects {
+		projects[i].Tasks = s.filter(projects[i].Tasks)
+	}
+
+	data, err := json.Marshal(projects)
+	if err != nil {
+		return errors.Wrap(err, "failed to hash projects")
+	}
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.snapshot != nil && s.snapshot.hash == hash {
+		return nil
+	}
+	s.snapshot = &snapshot{
+		projects: projects,
+		hash:     hash,
+		modified: time.Now().UTC().Truncate(time.Second),
+	}
+	log.Debug().Time("modified", s.snapshot.modified).Msg("feeds refreshed")
+	return nil
+}
+
+func (s *Server) filter(tasks []types.Task) []types.Task {
+	var filtered []types.Task
+	for _, t := range tasks {
+		if t.Priority < s.opts.Priority {
+			continue
+		}
+		if s.opts.Tag != "" && !slices.Contains(t.Tags, s.opts.Tag) {
+			continue
+		}
+		filtered = append(filtered, t)
+	}
+	return filtered
+}
+
+func (s *Server) current() (*snapshot, error) {
+	if s.opts.Interval <= 0 {
+		if err := s.Refresh(); err != nil {
+			return nil, err
+		}
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.snapshot == nil {
+		return nil, errors.New("feeds are not loaded yet")
+	}
+	return s.snapshot, nil
+}
+
+// projects returns the snapshot projects, narrowed to the project in the request path if any
+func (s *Server) projects(w http.ResponseWriter, r *http.Request) (*snapshot, []types.ProjectData, bool) {
+	snap, err := s.current()
+	if err != nil {
+		log.Error().Err(err).Msg("failed to load feeds")
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return nil, nil, false
+	}
+	id := r.PathValue("id")
+	if id == "" {
+		return snap, snap.projects, true
+	}
+	for _, p := range snap.projects {
+		if p.Project.ID == id {
+			return snap, []types.ProjectData{p}, true
+		}
+	}
+	http.NotFound(w, r)
+	return nil, nil, false
+}
+
+func (s *Server) handleICS(w http.ResponseWriter, r *http.Request) {
+	snap, projects, ok := s.projects(w, r)
+	if !ok {
+		return
+	}
+	var buf bytes.Buffer
+	err := export.ICS(&buf, projects, export.ICSOptions{
+		Name:      s.opts.Name,
+		Component: s.opts.Component,
+		Stamp:     snap.modified,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	serve(w, r, "text/calendar; charset=utf-8", buf.Bytes(), snap.modified)
+}
+
+func (s *Server) handleJSON(w http.ResponseWriter, r *http.Request) {
+	snap, projects, ok := s.projects(w, r)
+	if !ok {
+		return
+	}
+	tasks := []types.Task{}
+	for _, p := range projects {
+		tasks = append(tasks, p.Tasks...)
+	}
+	data, err := json.MarshalIndent(tasks, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	serve(w, r, "application/json", data, snap.modified)
+}
+
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintln(w, "tickli feeds:")
+	if s.opts.ICS {
+		fmt.Fprintln(w, "  /calendar.ics")
+		fmt.Fprintln(w, "  /projects/{id}/calendar.ics")
+	}
+	if s.opts.JSON {
+		fmt.Fprintln(w, "  /tasks.json")
+		fmt.Fprintln(w, "  /projects/{id}/tasks.json")
+	}
+}
+
+// serve writes the body with validators, answering conditional requests with 304 Not Modified
+func serve(w http.ResponseWriter, r *http.Request, contentType string, body []byte, modified time.Time) {
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Last-Modified", modified.Format(http.TimeFormat))
+	w.Header().Set("Cache-Control", "no-cache")
+
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		for _, candidate := range strings.Split(match, ",") {
+			// If-None-Match uses the weak comparison, ignoring the W/ prefix
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == etag || candidate == "*" {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+	} else if since, err := http.ParseTime(r.Header.Get("If-Modified-Since")); err == nil && !modified.After(since) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	_, _ = w.Write(body)
+}
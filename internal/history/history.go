@@ -0,0 +1,204 @@
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/types"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// MaxEntries is how many entries the history keeps, older ones are dropped
+const MaxEntries = 1000
+
+// Op is a mutation recorded in the history
+type Op string
+
+const (
+	OpTaskCreate    Op = "task.create"
+	OpTaskUpdate    Op = "task.update"
+	OpTaskComplete  Op = "task.complete"
+	OpTaskDelete    Op = "task.delete"
+	OpTaskMove      Op = "task.move"
+	OpProjectCreate Op = "project.create"
+	OpProjectUpdate Op = "project.update"
+	OpProjectDelete Op = "project.delete"
+)
+
+// Entry is a mutation with what is needed to revert it: the state of the task or project before it
+// changed, or the created one for creations
+type Entry struct {
+	ID      int            `json:"id"`
+	Time    time.Time      `json:"time"`
+	Op      Op             `json:"op"`
+	Task    *types.Task    `json:"task,omitempty"`
+	Project *types.Project `json:"project,omitempty"`
+	// Tasks are the open tasks of a deleted project
+	Tasks []types.Task `json:"tasks,omitempty"`
+	// ToProjectID and NewID are where a moved task went, and its ID there
+	ToProjectID string `json:"toProjectId,omitempty"`
+	NewID       string `json:"newId,omitempty"`
+	Undone      bool   `json:"undone,omitempty"`
+	// Replaced maps the IDs of the tasks and projects recreated by undoing the entry to their new IDs
+	Replaced map[string]string `json:"replaced,omitempty"`
+}
+
+// Summary describes the mutation in a few words
+func (e Entry) Summary() string {
+	switch e.Op {
+	case OpTaskCreate:
+		return fmt.Sprintf("create task %q", e.Task.Title)
+	case OpTaskUpdate:
+		return fmt.Sprintf("update task %q", e.Task.Title)
+	case OpTaskComplete:
+		return fmt.Sprintf("complete task %q", e.Task.Title)
+	case OpTaskDelete:
+		return fmt.Sprintf("delete task %q", e.Task.Title)
+	case OpTaskMove:
+		return fmt.Sprintf("move task %q to project %s", e.Task.Title, e.ToProjectID)
+	case OpProjectCreate:
+		return fmt.Sprintf("create project %q", e.Project.Name)
+	case OpProjectUpdate:
+		return fmt.Sprintf("update project %q", e.Project.Name)
+	case OpProjectDelete:
+		return fmt.Sprintf("delete project %q with %d tasks", e.Project.Name, len(e.Tasks))
+	}
+	return string(e.Op)
+}
+
+// Log is a file of recorded mutations, one JSON entry per line, safe for concurrent use
+type Log struct {
+	path string
+	mu   sync.Mutex
+}
+
+func Open(path string) *Log {
+	return &Log{path: path}
+}
+
+// Append records the entry, giving it the next ID, and drops the oldest entries past MaxEntries
+func (l *Log) Append(entry Entry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entries, err := l.read()
+	if err != nil {
+		return err
+	}
+	entry.ID = 1
+	if len(entries) > 0 {
+		entry.ID = entries[len(entries)-1].ID + 1
+	}
+	entry.Time = time.Now()
+	if len(entries) >= MaxEntries {
+		return l.write(append(entries[len(entries)-MaxEntries+1:], entry))
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "encoding history entry")
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), 0700); err != nil {
+		return errors.Wrap(err, "creating history directory")
+	}
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrap(err, "opening history")
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return errors.Wrap(err, "writing history")
+	}
+	return nil
+}
+
+// Replaced returns the new IDs of every task and project recreated by undoing entries
+func Replaced(entries []Entry) map[string]string {
+	ids := make(map[string]string)
+	for _, e := range entries {
+		for old, id := range e.Replaced {
+			ids[old] = id
+		}
+	}
+	return ids
+}
+
+// Entries returns the recorded entries, oldest first
+func (l *Log) Entries() ([]Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.read()
+}
+
+// MarkUndone flags the entry as undone so it isn't undone twice, with the IDs undoing it replaced
+func (l *Log) MarkUndone(id int, replaced map[string]string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entries, err := l.read()
+	if err != nil {
+		return err
+	}
+	idx := slices.IndexFunc(entries, func(e Entry) bool { return e.ID == id })
+	if idx < 0 {
+		return fmt.Errorf("history entry %d not found", id)
+	}
+	entries[idx].Undone = true
+	if len(replaced) > 0 {
+		entries[idx].Replaced = replaced
+	}
+	return l.write(entries)
+}
+
+func (l *Log) read() ([]Entry, error) {
+	f, err := os.Open(l.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "opening history")
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, errors.Wrap(err, "decoding history entry")
+		}
+		entries = append(entries, e)
+	}
+	return entries, errors.Wrap(scanner.Err(), "reading history")
+}
+
+// write replaces the file atomically with the entries
+func (l *Log) write(entries []Entry) error {
+	var buf bytes.Buffer
+	for _, e := range entries {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return errors.Wrap(err, "encoding history entry")
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), 0700); err != nil {
+		return errors.Wrap(err, "creating history directory")
+	}
+	tmp := l.path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0600); err != nil {
+		return errors.Wrap(err, "writing history")
+	}
+	return os.Rename(tmp, l.path)
+}
@@ -0,0 +1,41 @@
+package importer
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+)
+
+// Format is a file format tasks can be imported from
+type Format string
+
+const (
+	FormatCSV     Format = "csv"
+	FormatJSON    Format = "json"
+	FormatTodoTxt Format = "todotxt"
+)
+
+var FormatCompletion = []cobra.Completion{
+	cobra.CompletionWithDesc(string(FormatCSV), "CSV with a header row"),
+	cobra.CompletionWithDesc(string(FormatJSON), "JSON array of task objects"),
+	cobra.CompletionWithDesc(string(FormatTodoTxt), "todo.txt lines"),
+}
+
+var FormatCompletionFunc = cobra.FixedCompletions(FormatCompletion, cobra.ShellCompDirectiveNoFileComp)
+
+func (f *Format) Set(value string) error {
+	switch Format(value) {
+	case FormatCSV, FormatJSON, FormatTodoTxt:
+		*f = Format(value)
+	default:
+		return fmt.Errorf("invalid import format: %s", value)
+	}
+	return nil
+}
+
+func (f Format) String() string {
+	return string(f)
+}
+
+func (f *Format) Type() string {
+	return "ImportFormat"
+}
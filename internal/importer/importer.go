@@ -0,0 +1,254 @@
+package importer
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/types/project"
+	"github.com/sho0pi/tickli/internal/types/task"
+	"strings"
+	"time"
+)
+
+type Options struct {
+	// DefaultProject is the name or ID of the project of records without one
+	DefaultProject string
+	// CreateProjects creates the projects that cannot be resolved instead of failing the record
+	CreateProjects bool
+	DryRun         bool
+}
+
+type Action string
+
+const (
+	ActionCreated Action = "created"
+	ActionSkipped Action = "skipped"
+	ActionFailed  Action = "failed"
+)
+
+type Result struct {
+	Record  Record
+	Action  Action
+	Project types.Project
+	TaskID  string
+	Err     error
+}
+
+type Summary struct {
+	Created, Skipped, Failed int
+}
+
+// Importer creates the records as tasks, skipping the ones already imported by a previous run
+type Importer struct {
+	client *api.Client
+	opts   Options
+
+	projects []types.Project
+	// imported holds the external IDs found in the tasks of each project
+	imported map[string]map[string]string
+	// existing holds the IDs of the tasks of each project
+	existing map[string]map[string]bool
+	// columns holds the kanban columns of each project, fetched when a record has a column
+	columns map[string][]types.Column
+}
+
+func New(client *api.Client, opts Options) *Importer {
+	return &Importer{
+		client:   client,
+		opts:     opts,
+		imported: make(map[string]map[string]string),
+		existing: make(map[string]map[string]bool),
+		columns:  make(map[string][]types.Column),
+	}
+}
+
+// Import creates the records in order, reporting the result of each one
+func (im *Importer) Import(records []Record, report func(Result)) (Summary, error) {
+	var summary Summary
+	projects, err := im.client.ListProjects()
+	if err != nil {
+		return summary, errors.Wrap(err, "failed to list projects")
+	}
+	im.projects = projects
+
+	for _, record := range records {
+		result := im.importRecord(record)
+		switch result.Action {
+		case ActionCreated:
+			summary.Created++
+		case ActionSkipped:
+			summary.Skipped++
+		case ActionFailed:
+			summary.Failed++
+		}
+		report(result)
+	}
+	return summary, nil
+}
+
+func (im *Importer) importRecord(record Record) Result {
+	result := Result{Record: record}
+	p, err := im.resolveProject(record.Project, record.ProjectViewMode)
+	if err != nil {
+		result.Action, result.Err = ActionFailed, err
+		return result
+	}
+	result.Project = p
+
+	imported, err := im.importedIDs(p.ID)
+	if err != nil {
+		result.Action, result.Err = ActionFailed, err
+		return result
+	}
+	if taskID, ok := imported[record.ExternalID]; ok {
+		result.Action, result.TaskID = ActionSkipped, taskID
+		return result
+	}
+	if record.TaskID != "" && im.existing[p.ID][record.TaskID] {
+		result.Action, result.TaskID = ActionSkipped, record.TaskID
+		return result
+	}
+
+	t := record.Task
+	if record.Column != "" {
+		column, err := im.findColumn(p.ID, record.Column)
+		if err != nil {
+			result.Action, result.Err = ActionFailed, err
+			return result
+		}
+		t.ColumnID = column.ID
+		if column.ID == "" && !strings.HasPrefix(p.ID, "new:") {
+			result.Err = fmt.Errorf("column %q not found, the task is added to the first column", record.Column)
+		}
+	}
+
+	result.Action = ActionCreated
+	if im.opts.DryRun {
+		imported[record.ExternalID] = ""
+		return result
+	}
+
+	t.ProjectID = p.ID
+	t.Content = WithMarker(t.Content, record.ExternalID)
+	completed := t.Status == task.StatusComplete
+	t.Status = task.StatusNormal
+	created, err := im.client.CreateTask(&t)
+	if err != nil {
+		result.Action, result.Err = ActionFailed, err
+		return result
+	}
+	result.TaskID = created.ID
+	imported[record.ExternalID] = created.ID
+
+	if completed {
+		if err := im.client.CompleteTask(p.ID, created.ID); err != nil {
+			result.Err = errors.Wrap(err, "created but failed to complete")
+		}
+	}
+	return result
+}
+
+// ResolveProject finds a project by ID or name, creating it if allowed
+func (im *Importer) ResolveProject(nameOrID string) (types.Project, error) {
+	return im.resolveProject(nameOrID, "")
+}
+
+func (im *Importer) resolveProject(nameOrID string, viewMode project.ViewMode) (types.Project, error) {
+	if nameOrID == "" {
+		nameOrID = im.opts.DefaultProject
+	}
+	if nameOrID == "" {
+		return types.InboxProject, nil
+	}
+	if p, ok := api.FindProject(im.projects, nameOrID); ok {
+		return p, nil
+	}
+	if !im.opts.CreateProjects {
+		return types.NullProject, fmt.Errorf("project %q not found, use --create-projects to create it", nameOrID)
+	}
+
+	p := types.Project{
+		Name:     nameOrID,
+		Color:    project.DefaultColor,
+		Kind:     project.KindTask,
+		ViewMode: project.ViewModeList,
+	}
+	if viewMode == project.ViewModeKanban || viewMode == project.ViewModeTimeline {
+		p.ViewMode = viewMode
+	}
+	if im.opts.DryRun {
+		p.ID = "new:" + nameOrID
+	} else {
+		created, err := im.client.CreateProject(&p)
+		if err != nil {
+			return types.NullProject, errors.Wrap(err, fmt.Sprintf("failed to create project %s", nameOrID))
+		}
+		p = *created
+	}
+	im.projects = append(im.projects, p)
+	return p, nil
+}
+
+// importedIDs returns the external IDs of the open and completed tasks of the project, recording
+// the IDs of the tasks as existing. Project data only holds open tasks, so completed ones are listed
+// through their own endpoint; when it fails, completed records may be imported again.
+func (im *Importer) importedIDs(projectID string) (map[string]string, error) {
+	if ids, ok := im.imported[projectID]; ok {
+		return ids, nil
+	}
+	ids := make(map[string]string)
+	existing := make(map[string]bool)
+	if !strings.HasPrefix(projectID, "new:") {
+		tasks, err := im.client.ListTasks(projectID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list existing tasks")
+		}
+		completed, err := im.client.ListCompletedTasks([]string{projectID}, time.Unix(0, 0), time.Now())
+		if err != nil {
+			log.Warn().Err(err).Str("project", projectID).
+				Msg("Failed to list completed tasks, completed tasks imported before may be imported again")
+		}
+		for _, t := range append(tasks, completed...) {
+			existing[t.ID] = true
+			if id, ok := ExternalID(t.Content); ok {
+				ids[id] = t.ID
+			}
+		}
+	}
+	im.imported[projectID] = ids
+	im.existing[projectID] = existing
+	return ids, nil
+}
+
+// findColumn finds a kanban column of the project by name, the zero column if there is none.
+// Columns cannot be created through the API, new projects only have their default column
+func (im *Importer) findColumn(projectID, name string) (types.Column, error) {
+	columns, ok := im.columns[projectID]
+	if !ok && !strings.HasPrefix(projectID, "new:") {
+		data, err := im.client.GetProjectWithTasks(projectID)
+		if err != nil {
+			return types.Column{}, errors.Wrap(err, "failed to get project columns")
+		}
+		columns = data.Columns
+	}
+	im.columns[projectID] = columns
+	for _, column := range columns {
+		if column.ID == name || sameName(column.Name, name) {
+			return column, nil
+		}
+	}
+	return types.Column{}, nil
+}
+
+// sameName compares names ignoring case and treating dashes and underscores as spaces,
+// so names written as todo.txt words still match
+func sameName(a, b string) bool {
+	normalize := func(s string) string {
+		return strings.ToLower(strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+			return r == ' ' || r == '-' || r == '_'
+		}), " "))
+	}
+	return strings.EqualFold(a, b) || normalize(a) == normalize(b)
+}
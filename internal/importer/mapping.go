@@ -0,0 +1,217 @@
+package importer
+
+import (
+	"fmt"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/types/task"
+	"github.com/sho0pi/tickli/internal/utils"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Task fields a source column can be mapped to, named like the output --fields
+const (
+	FieldID       = "id"
+	FieldTitle    = "title"
+	FieldContent  = "content"
+	FieldProject  = "project"
+	FieldPriority = "priority"
+	FieldTags     = "tags"
+	FieldStart    = "start"
+	FieldDue      = "due"
+	FieldAllDay   = "allDay"
+	FieldTimeZone = "timeZone"
+	FieldStatus   = "status"
+)
+
+// fieldAliases are the column names recognised for each field when no mapping is given, in order of
+// preference when several are present
+var fieldAliases = map[string][]string{
+	FieldID:       {"id", "external id", "externalid", "uid", "uuid"},
+	FieldTitle:    {"title", "name", "task", "summary", "subject"},
+	FieldContent:  {"content", "notes", "note", "description", "desc", "details"},
+	FieldProject:  {"project", "projectid", "list", "list name", "folder"},
+	FieldPriority: {"priority", "prio"},
+	FieldTags:     {"tags", "tag", "labels", "label", "categories"},
+	FieldStart:    {"start", "startdate", "start date", "scheduled"},
+	FieldDue:      {"due", "duedate", "due date", "deadline"},
+	FieldAllDay:   {"allday", "all day", "isallday"},
+	FieldTimeZone: {"timezone", "time zone", "tz"},
+	FieldStatus:   {"status", "done", "completed", "state"},
+}
+
+// Mapping maps task fields to source column names
+type Mapping map[string]string
+
+// Resolve returns the column of each field, explicit mappings first and known aliases otherwise.
+// The first alias of a field found in the columns wins, whatever the order of the columns.
+func (m Mapping) Resolve(columns []string) (map[string]string, error) {
+	resolved := make(map[string]string)
+	for field, column := range m {
+		name, ok := canonicalField(field)
+		if !ok {
+			return nil, fmt.Errorf("unknown task field %q in mapping", field)
+		}
+		resolved[name] = column
+	}
+	for field, aliases := range fieldAliases {
+		if _, ok := resolved[field]; ok {
+			continue
+		}
+		for _, alias := range aliases {
+			idx := slices.IndexFunc(columns, func(column string) bool {
+				return strings.EqualFold(alias, strings.TrimSpace(column))
+			})
+			if idx >= 0 {
+				resolved[field] = columns[idx]
+				break
+			}
+		}
+	}
+	if _, ok := resolved[FieldTitle]; !ok {
+		return nil, fmt.Errorf("no title column found in %v, map one with --map title=<column>", columns)
+	}
+	return resolved, nil
+}
+
+func canonicalField(field string) (string, bool) {
+	for name := range fieldAliases {
+		if strings.EqualFold(name, field) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+func containsFold(values []string, s string) bool {
+	s = strings.TrimSpace(s)
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// newRecord builds a record from a row of column values
+func newRecord(row map[string]string, columns map[string]string, line int) (Record, error) {
+	get := func(field string) string {
+		column, ok := columns[field]
+		if !ok {
+			return ""
+		}
+		return strings.TrimSpace(row[column])
+	}
+
+	r := Record{
+		ExternalID: get(FieldID),
+		Project:    get(FieldProject),
+		Line:       line,
+		Task: types.Task{
+			Title:    get(FieldTitle),
+			Content:  get(FieldContent),
+			TimeZone: get(FieldTimeZone),
+			Tags:     ParseTags(get(FieldTags)),
+		},
+	}
+	if r.Task.Title == "" {
+		return r, fmt.Errorf("line %d: missing title", line)
+	}
+
+	var err error
+	if r.Task.Priority, err = ParsePriority(get(FieldPriority)); err != nil {
+		return r, fmt.Errorf("line %d: %w", line, err)
+	}
+	if ParseDone(get(FieldStatus)) {
+		r.Task.Status = task.StatusComplete
+	}
+
+	var startAllDay, dueAllDay bool
+	if r.Task.StartDate, startAllDay, err = ParseDate(get(FieldStart)); err != nil {
+		return r, fmt.Errorf("line %d: start: %w", line, err)
+	}
+	if r.Task.DueDate, dueAllDay, err = ParseDate(get(FieldDue)); err != nil {
+		return r, fmt.Errorf("line %d: due: %w", line, err)
+	}
+	// Tasks are all-day when every given date is a plain date
+	hasDate := !r.Task.StartDate.IsZero() || !r.Task.DueDate.IsZero()
+	r.Task.IsAllDay = hasDate && (r.Task.StartDate.IsZero() || startAllDay) && (r.Task.DueDate.IsZero() || dueAllDay)
+	if allDay := get(FieldAllDay); allDay != "" {
+		r.Task.IsAllDay = ParseDone(allDay)
+	}
+	return r, nil
+}
+
+// ParseTags splits tags separated by commas, semicolons or spaces, dropping any leading '#'
+func ParseTags(s string) []string {
+	var tags []string
+	for _, tag := range strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ';' || r == ' ' || r == '\t'
+	}) {
+		if tag = strings.TrimLeft(tag, "#@"); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// ParsePriority accepts priority names, their initials, todo.txt letters and TickTick values
+func ParsePriority(s string) (task.Priority, error) {
+	switch strings.ToLower(strings.Trim(s, "() ")) {
+	case "", "none", "0", "n":
+		return task.PriorityNone, nil
+	case "low", "l", "1", "c":
+		return task.PriorityLow, nil
+	case "medium", "m", "med", "3", "b":
+		return task.PriorityMedium, nil
+	case "high", "h", "5", "a":
+		return task.PriorityHigh, nil
+	default:
+		return task.PriorityNone, fmt.Errorf("invalid priority %q", s)
+	}
+}
+
+// ParseDone reports whether a status or boolean value means completed
+func ParseDone(s string) bool {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "x", "true", "yes", "y", "1", "2", "done", "completed", "complete":
+		return true
+	default:
+		return false
+	}
+}
+
+var dateLayouts = []struct {
+	layout string
+	allDay bool
+}{
+	{time.RFC3339, false},
+	{"2006-01-02T15:04:05-0700", false},
+	{"2006-01-02T15:04:05", false},
+	{"2006-01-02 15:04:05", false},
+	{"2006-01-02 15:04", false},
+	{"2006-01-02", true},
+	{"2006/01/02", true},
+}
+
+// ParseDate parses ISO dates and times, falling back to natural language such as "next friday"
+func ParseDate(s string) (types.TickTickTime, bool, error) {
+	if s == "" {
+		return types.TickTickTime{}, false, nil
+	}
+	for _, l := range dateLayouts {
+		if t, err := time.ParseInLocation(l.layout, s, time.Local); err == nil {
+			return types.TickTickTime(t), l.allDay, nil
+		}
+	}
+	if epoch, err := strconv.ParseInt(s, 10, 64); err == nil && epoch > 1e11 {
+		return types.TickTickTime(time.UnixMilli(epoch)), false, nil
+	}
+	r, err := utils.ParseTimeExpression(s)
+	if err != nil {
+		return types.TickTickTime{}, false, fmt.Errorf("invalid date %q", s)
+	}
+	return types.TickTickTime(r.Start()), r.IsAllDay(), nil
+}
@@ -0,0 +1,131 @@
+package importer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Read parses the records of a source in the given format
+func Read(r io.Reader, format Format, mapping Mapping) ([]Record, error) {
+	var (
+		records []Record
+		err     error
+	)
+	switch format {
+	case FormatCSV:
+		records, err = ReadCSV(r, mapping)
+	case FormatJSON:
+		records, err = ReadJSON(r, mapping)
+	case FormatTodoTxt:
+		records, err = ReadTodoTxt(r)
+	default:
+		return nil, fmt.Errorf("unsupported import format: %s", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i := range records {
+		if records[i].ExternalID == "" {
+			records[i].ExternalID = derivedID(string(format), records[i])
+		}
+	}
+	return records, nil
+}
+
+// ReadCSV parses a CSV file with a header row, detecting tab separated files from the header
+func ReadCSV(r io.Reader, mapping Mapping) ([]Record, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read csv")
+	}
+	reader := csv.NewReader(strings.NewReader(strings.TrimPrefix(string(data), "\ufeff")))
+	if header, _, _ := strings.Cut(string(data), "\n"); strings.Count(header, "\t") > strings.Count(header, ",") {
+		reader.Comma = '\t'
+	}
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read csv header")
+	}
+	columns, err := mapping.Resolve(header)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []Record
+	for line := 2; ; line++ {
+		values, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read csv")
+		}
+		row := make(map[string]string, len(header))
+		for i, value := range values {
+			if i < len(header) {
+				row[header[i]] = value
+			}
+		}
+		record, err := newRecord(row, columns, line)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// ReadJSON parses a JSON array of objects, such as the output of 'tickli task list -o json'
+func ReadJSON(r io.Reader, mapping Mapping) ([]Record, error) {
+	var objects []map[string]any
+	if err := json.NewDecoder(r).Decode(&objects); err != nil {
+		return nil, errors.Wrap(err, "failed to parse json, expected an array of objects")
+	}
+
+	var records []Record
+	for i, object := range objects {
+		row := make(map[string]string, len(object))
+		for key, value := range object {
+			row[key] = jsonString(value)
+		}
+		// Sorted so that keys matching the same field resolve the same way on every run
+		keys := slices.Sorted(maps.Keys(row))
+		columns, err := mapping.Resolve(keys)
+		if err != nil {
+			return nil, fmt.Errorf("object %d: %w", i+1, err)
+		}
+		record, err := newRecord(row, columns, i+1)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func jsonString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []any:
+		parts := make([]string, len(v))
+		for i, part := range v {
+			parts[i] = jsonString(part)
+		}
+		return strings.Join(parts, ",")
+	case float64:
+		return fmt.Sprint(int64(v))
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,73 @@
+package importer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/types/project"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Record is a task read from an import source
+type Record struct {
+	// ExternalID identifies the record in its source, it is stored in the created task to make imports idempotent
+	ExternalID string
+	// TaskID is the ID of the task the record was exported from, a record whose task still exists
+	// is matched to it instead of creating a task
+	TaskID string
+	// Project is the name or ID of the target project, empty for the default project
+	Project string
+	// Column is the name of the kanban column of the task, if any
+	Column string
+	// ProjectViewMode is the view mode of the project when it has to be created
+	ProjectViewMode project.ViewMode
+	Task            types.Task
+	// Line locates the record in its source for error reporting
+	Line int
+}
+
+const markerPrefix = "tickli-import:"
+
+// markerPattern captures the rest of the line, as IDs read from a mapped column may hold spaces
+var markerPattern = regexp.MustCompile(`(?m)^` + regexp.QuoteMeta(markerPrefix) + `[ \t]*(\S.*?)\s*$`)
+
+// Marker returns the content line marking a task as imported from the given external ID
+func Marker(externalID string) string {
+	return markerPrefix + " " + externalID
+}
+
+// WithMarker appends the import marker to the task content
+func WithMarker(content, externalID string) string {
+	if strings.TrimSpace(content) == "" {
+		return Marker(externalID)
+	}
+	return strings.TrimRight(content, "\n") + "\n\n" + Marker(externalID)
+}
+
+// ExternalID returns the external ID marked in the task content, if any
+func ExternalID(content string) (string, bool) {
+	m := markerPattern.FindStringSubmatch(content)
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+// derivedID identifies records without an ID of their own by their project, title and dates,
+// so re-running an unchanged import matches the tasks created by the previous run
+func derivedID(source string, r Record) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00%s\x00%s", source, r.Project, r.Task.Title,
+		formatKey(r.Task.StartDate), formatKey(r.Task.DueDate))
+	return source + "-" + hex.EncodeToString(h.Sum(nil))[:12]
+}
+
+func formatKey(t types.TickTickTime) string {
+	if t.IsZero() {
+		return ""
+	}
+	return time.Time(t).UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,108 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/export"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/types/task"
+	"io"
+	"strings"
+	"time"
+)
+
+// ReadTaskwarrior parses the output of 'task export', either a JSON array or one task per line.
+// Deleted tasks and recurring templates are left out, their pending instances are imported,
+// and annotations are joined into the task content. Tasks exported by tickli are matched to the task
+// of their ticktickid attribute when it still exists.
+func ReadTaskwarrior(r io.Reader) ([]Record, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read taskwarrior export")
+	}
+
+	var tasks []export.TaskwarriorTask
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &tasks); err != nil {
+			return nil, errors.Wrap(err, "failed to parse taskwarrior export")
+		}
+	} else {
+		dec := json.NewDecoder(bytes.NewReader(trimmed))
+		for dec.More() {
+			var tw export.TaskwarriorTask
+			if err := dec.Decode(&tw); err != nil {
+				return nil, errors.Wrap(err, "failed to parse taskwarrior export")
+			}
+			tasks = append(tasks, tw)
+		}
+	}
+
+	var records []Record
+	for i, tw := range tasks {
+		if tw.Status == export.TaskwarriorDeleted || tw.Status == export.TaskwarriorRecurring {
+			continue
+		}
+		record, err := newTaskwarriorRecord(tw, i+1)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func newTaskwarriorRecord(tw export.TaskwarriorTask, line int) (Record, error) {
+	record := Record{
+		ExternalID: "taskwarrior-" + tw.UUID,
+		TaskID:     tw.TickTickID,
+		Project:    tw.Project,
+		Line:       line,
+	}
+	t := &record.Task
+	t.Title = strings.TrimSpace(tw.Description)
+	if t.Title == "" {
+		return record, fmt.Errorf("task %d: missing description", line)
+	}
+	t.Tags = tw.Tags
+
+	var err error
+	if t.Priority, err = ParsePriority(tw.Priority); err != nil {
+		return record, errors.Wrapf(err, "task %d", line)
+	}
+	var startAllDay, dueAllDay bool
+	if t.StartDate, startAllDay, err = parseTaskwarriorTime(tw.Scheduled); err != nil {
+		return record, errors.Wrapf(err, "task %d", line)
+	}
+	if t.DueDate, dueAllDay, err = parseTaskwarriorTime(tw.Due); err != nil {
+		return record, errors.Wrapf(err, "task %d", line)
+	}
+	t.IsAllDay = (t.StartDate.IsZero() || startAllDay) && (t.DueDate.IsZero() || dueAllDay) &&
+		!(t.StartDate.IsZero() && t.DueDate.IsZero())
+	if tw.Status == export.TaskwarriorCompleted {
+		t.Status = task.StatusComplete
+		t.CompletedTime, _, _ = parseTaskwarriorTime(tw.End)
+	}
+
+	notes := make([]string, len(tw.Annotations))
+	for i, annotation := range tw.Annotations {
+		notes[i] = annotation.Description
+	}
+	t.Content = strings.Join(notes, "\n")
+	return record, nil
+}
+
+// parseTaskwarriorTime parses a Taskwarrior UTC date, dates at local midnight being all day
+func parseTaskwarriorTime(s string) (types.TickTickTime, bool, error) {
+	if s == "" {
+		return types.TickTickTime{}, false, nil
+	}
+	t, err := time.Parse(export.TaskwarriorTimeLayout, s)
+	if err != nil {
+		return ParseDate(s)
+	}
+	local := t.Local()
+	allDay := local.Hour() == 0 && local.Minute() == 0 && local.Second() == 0
+	return types.TickTickTime(local), allDay, nil
+}
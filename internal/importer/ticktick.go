@@ -0,0 +1,212 @@
+package importer
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/types/project"
+	"github.com/sho0pi/tickli/internal/types/task"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Columns of the backup CSV exported from the TickTick web settings
+const (
+	backupList          = "List Name"
+	backupTitle         = "Title"
+	backupKind          = "Kind"
+	backupTags          = "Tags"
+	backupContent       = "Content"
+	backupStart         = "Start Date"
+	backupDue           = "Due Date"
+	backupReminder      = "Reminder"
+	backupRepeat        = "Repeat"
+	backupPriority      = "Priority"
+	backupStatus        = "Status"
+	backupCompleted     = "Completed Time"
+	backupOrder         = "Order"
+	backupTimeZone      = "Timezone"
+	backupAllDay        = "Is All Day"
+	backupColumn        = "Column Name"
+	backupViewMode      = "View Mode"
+	backupTaskID        = "taskId"
+	backupParentID      = "parentId"
+	backupChecklistKind = "CHECKLIST"
+)
+
+// Checklist items are written in the content of CHECKLIST tasks, one per line
+const (
+	backupItemOpen = "▫"
+	backupItemDone = "▪"
+)
+
+// ReadTickTickBackup parses a TickTick backup CSV. The backup starts with a few lines of
+// metadata before its header, lists map to projects, checklist lines of the content to
+// items, and subtasks are folded into checklist items of their parent task
+func ReadTickTickBackup(r io.Reader) ([]Record, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read backup")
+	}
+	reader := csv.NewReader(strings.NewReader(strings.TrimPrefix(string(data), "\ufeff")))
+	reader.FieldsPerRecord = -1
+
+	var header []string
+	for header == nil {
+		values, err := reader.Read()
+		if err == io.EOF {
+			return nil, errors.New("not a TickTick backup, no header with a \"List Name\" and \"Title\" column found")
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read backup")
+		}
+		if containsFold(values, backupList) && containsFold(values, backupTitle) {
+			header = values
+		}
+	}
+
+	var (
+		records  []Record
+		byTaskID = make(map[string]int)
+		children []Record
+		parents  []string
+	)
+	for {
+		values, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read backup")
+		}
+		line, _ := reader.FieldPos(0)
+		row := make(map[string]string, len(header))
+		for i, value := range values {
+			if i < len(header) {
+				row[header[i]] = value
+			}
+		}
+
+		record, err := newBackupRecord(row, line)
+		if err != nil {
+			return nil, err
+		}
+		if parent := row[backupParentID]; parent != "" {
+			children = append(children, record)
+			parents = append(parents, parent)
+			continue
+		}
+		if id := row[backupTaskID]; id != "" {
+			byTaskID[id] = len(records)
+		}
+		records = append(records, record)
+	}
+
+	for i, child := range children {
+		parent, ok := byTaskID[parents[i]]
+		if !ok {
+			records = append(records, child)
+			continue
+		}
+		item := types.ChecklistItem{Title: child.Task.Title, StartDate: child.Task.StartDate}
+		if child.Task.Status == task.StatusComplete {
+			item.Status = 1
+		}
+		records[parent].Task.Items = append(records[parent].Task.Items, item)
+	}
+	for i := range records {
+		if records[i].ExternalID == "" {
+			records[i].ExternalID = derivedID("ticktick", records[i])
+		}
+	}
+	return records, nil
+}
+
+func newBackupRecord(row map[string]string, line int) (Record, error) {
+	record := Record{
+		Project:         row[backupList],
+		Column:          row[backupColumn],
+		ProjectViewMode: project.ViewMode(strings.ToLower(row[backupViewMode])),
+		Line:            line,
+	}
+	if id := row[backupTaskID]; id != "" {
+		record.ExternalID = "ticktick-" + id
+	}
+
+	t := &record.Task
+	t.Title = strings.TrimSpace(row[backupTitle])
+	if t.Title == "" {
+		return record, fmt.Errorf("line %d: missing title", line)
+	}
+	t.Tags = ParseTags(row[backupTags])
+	t.TimeZone = row[backupTimeZone]
+	t.IsAllDay = strings.EqualFold(row[backupAllDay], "true")
+	t.RepeatFlag = row[backupRepeat]
+	if t.RepeatFlag != "" && !strings.HasPrefix(t.RepeatFlag, "RRULE:") {
+		t.RepeatFlag = "RRULE:" + t.RepeatFlag
+	}
+	for _, reminder := range strings.Split(row[backupReminder], ",") {
+		if reminder = strings.TrimSpace(reminder); reminder == "" {
+			continue
+		}
+		if !strings.HasPrefix(reminder, "TRIGGER:") {
+			reminder = "TRIGGER:" + reminder
+		}
+		t.Reminders = append(t.Reminders, reminder)
+	}
+
+	var err error
+	if order := row[backupOrder]; order != "" {
+		if t.SortOrder, err = strconv.ParseInt(order, 10, 64); err != nil {
+			return record, fmt.Errorf("line %d: invalid order %q", line, order)
+		}
+	}
+	if t.Priority, err = ParsePriority(row[backupPriority]); err != nil {
+		return record, errors.Wrapf(err, "line %d", line)
+	}
+	if t.StartDate, _, err = ParseDate(row[backupStart]); err != nil {
+		return record, errors.Wrapf(err, "line %d", line)
+	}
+	if t.DueDate, _, err = ParseDate(row[backupDue]); err != nil {
+		return record, errors.Wrapf(err, "line %d", line)
+	}
+	// The backup uses 1 for completed and 2 for archived tasks, both are done
+	if status := strings.TrimSpace(row[backupStatus]); status != "" && status != "0" {
+		t.Status = task.StatusComplete
+		t.CompletedTime, _, _ = ParseDate(row[backupCompleted])
+	}
+
+	if strings.EqualFold(row[backupKind], backupChecklistKind) {
+		t.Desc, t.Items = backupChecklist(row[backupContent])
+	} else {
+		t.Content = row[backupContent]
+	}
+	return record, nil
+}
+
+// backupChecklist splits the content of a CHECKLIST task into its description and items
+func backupChecklist(content string) (string, []types.ChecklistItem) {
+	var (
+		desc  []string
+		items []types.ChecklistItem
+	)
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, backupItemOpen):
+			items = append(items, types.ChecklistItem{
+				Title: strings.TrimSpace(strings.TrimPrefix(trimmed, backupItemOpen)),
+			})
+		case strings.HasPrefix(trimmed, backupItemDone):
+			items = append(items, types.ChecklistItem{
+				Title:  strings.TrimSpace(strings.TrimPrefix(trimmed, backupItemDone)),
+				Status: 1,
+			})
+		default:
+			desc = append(desc, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(desc, "\n")), items
+}
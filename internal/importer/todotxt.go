@@ -0,0 +1,139 @@
+package importer
+
+import (
+	"bufio"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/types/task"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	todoTxtPriority = regexp.MustCompile(`^\(([A-Z])\)$`)
+	todoTxtKeyValue = regexp.MustCompile(`^([^:\s]+):([^:\s]+)$`)
+)
+
+// ReadTodoTxt parses todo.txt lines: +project selects the project, @contexts become tags,
+// due: and t: set the dates, pri: the priority of completed tasks, column: the kanban column, id: is
+// the external ID, and lines with a parent: key become checklist items of that task, as written by
+// 'tickli project export --format todotxt'
+func ReadTodoTxt(r io.Reader) ([]Record, error) {
+	var records []Record
+	byID := make(map[string]int)
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		tokens := strings.Fields(scanner.Text())
+		if len(tokens) == 0 {
+			continue
+		}
+
+		record := Record{Line: line}
+		done := false
+		if tokens[0] == "x" {
+			done = true
+			tokens = tokens[1:]
+			if len(tokens) > 0 {
+				if completed, err := time.ParseInLocation("2006-01-02", tokens[0], time.Local); err == nil {
+					record.Task.CompletedTime = types.TickTickTime(completed)
+					tokens = tokens[1:]
+				}
+			}
+		}
+		if len(tokens) > 0 {
+			if m := todoTxtPriority.FindStringSubmatch(tokens[0]); m != nil {
+				record.Task.Priority = todoTxtLetterPriority(m[1])
+				tokens = tokens[1:]
+			}
+		}
+		// Skip the creation date
+		if len(tokens) > 0 {
+			if _, err := time.Parse("2006-01-02", tokens[0]); err == nil {
+				tokens = tokens[1:]
+			}
+		}
+
+		var title []string
+		var parent string
+		for _, token := range tokens {
+			switch {
+			case strings.HasPrefix(token, "+") && len(token) > 1:
+				if record.Project == "" {
+					record.Project = token[1:]
+				} else {
+					record.Task.Tags = append(record.Task.Tags, token[1:])
+				}
+			case strings.HasPrefix(token, "@") && len(token) > 1:
+				record.Task.Tags = append(record.Task.Tags, token[1:])
+			case todoTxtKeyValue.MatchString(token):
+				m := todoTxtKeyValue.FindStringSubmatch(token)
+				var err error
+				switch m[1] {
+				case "due":
+					record.Task.DueDate, _, err = ParseDate(m[2])
+					record.Task.IsAllDay = true
+				case "t":
+					record.Task.StartDate, _, err = ParseDate(m[2])
+					record.Task.IsAllDay = true
+				case "id":
+					record.ExternalID = m[2]
+				case "parent":
+					parent = m[2]
+				case "column":
+					record.Column = m[2]
+				case "pri":
+					if len(m[2]) == 1 && m[2] >= "A" && m[2] <= "Z" {
+						record.Task.Priority = todoTxtLetterPriority(m[2])
+					} else {
+						title = append(title, token)
+					}
+				default:
+					title = append(title, token)
+				}
+				if err != nil {
+					return nil, errors.Wrapf(err, "line %d", line)
+				}
+			default:
+				title = append(title, token)
+			}
+		}
+		record.Task.Title = strings.Join(title, " ")
+		if done {
+			record.Task.Status = task.StatusComplete
+		}
+
+		if i, ok := byID[parent]; ok && parent != "" {
+			item := types.ChecklistItem{Title: record.Task.Title}
+			if done {
+				item.Status = 1
+			}
+			records[i].Task.Items = append(records[i].Task.Items, item)
+			continue
+		}
+		if record.Task.Title == "" {
+			return nil, errors.Errorf("line %d: missing title", line)
+		}
+		if record.ExternalID != "" {
+			byID[record.ExternalID] = len(records)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read todo.txt")
+	}
+	return records, nil
+}
+
+func todoTxtLetterPriority(letter string) task.Priority {
+	switch letter {
+	case "A":
+		return task.PriorityHigh
+	case "B":
+		return task.PriorityMedium
+	default:
+		return task.PriorityLow
+	}
+}
@@ -0,0 +1,184 @@
+package journal
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/types/task"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Op is a task mutation recorded in the journal
+type Op string
+
+const (
+	OpCreate   Op = "create"
+	OpUpdate   Op = "update"
+	OpComplete Op = "complete"
+	OpDelete   Op = "delete"
+)
+
+// localIDPrefix marks the temporary IDs of tasks created while offline
+const localIDPrefix = "local-"
+
+// Entry is a mutation waiting to be sent to the server
+type Entry struct {
+	Seq       int       `json:"seq"`
+	Op        Op        `json:"op"`
+	Time      time.Time `json:"time"`
+	ProjectID string    `json:"projectId"`
+	TaskID    string    `json:"taskId"`
+	// Task is the state written by create and update entries
+	Task *types.Task `json:"task,omitempty"`
+	// Base is the server copy of the task when the change was made, used to detect conflicting changes
+	Base *types.Task `json:"base,omitempty"`
+}
+
+// IsLocalID reports whether the ID is a temporary ID given to a task created while offline
+func IsLocalID(id string) bool {
+	return strings.HasPrefix(id, localIDPrefix)
+}
+
+// Journal is an append-only file of queued mutations, one JSON entry per line. It may be shared by
+// goroutines, like the workers of bulk task commands.
+type Journal struct {
+	path string
+	mu   sync.Mutex
+}
+
+func Open(path string) *Journal {
+	return &Journal{path: path}
+}
+
+// Append records the mutation, giving it the next sequence number and created tasks a local ID
+func (j *Journal) Append(entry Entry) (Entry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	entries, err := j.Entries()
+	if err != nil {
+		return entry, err
+	}
+	entry.Seq = 1
+	for _, e := range entries {
+		entry.Seq = max(entry.Seq, e.Seq+1)
+	}
+	entry.Time = time.Now()
+	if entry.Op == OpCreate {
+		entry.TaskID = fmt.Sprintf("%s%d", localIDPrefix, entry.Seq)
+		task := *entry.Task
+		task.ID = entry.TaskID
+		entry.Task = &task
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return entry, errors.Wrap(err, "encoding journal entry")
+	}
+	if err := os.MkdirAll(filepath.Dir(j.path), 0700); err != nil {
+		return entry, errors.Wrap(err, "creating journal directory")
+	}
+	f, err := os.OpenFile(j.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return entry, errors.Wrap(err, "opening journal")
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return entry, errors.Wrap(err, "writing journal")
+	}
+	return entry, nil
+}
+
+// Entries returns the queued mutations in order
+func (j *Journal) Entries() ([]Entry, error) {
+	f, err := os.Open(j.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "opening journal")
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, errors.Wrapf(err, "journal line %d", line)
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "reading journal")
+	}
+	return entries, nil
+}
+
+// Replace rewrites the journal with the given entries, removing it when there are none left
+func (j *Journal) Replace(entries []Entry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if len(entries) == 0 {
+		if err := os.Remove(j.path); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "removing journal")
+		}
+		return nil
+	}
+
+	var buf bytes.Buffer
+	for _, e := range entries {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return errors.Wrap(err, "encoding journal entry")
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	tmp := j.path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0600); err != nil {
+		return errors.Wrap(err, "writing journal")
+	}
+	return os.Rename(tmp, j.path)
+}
+
+// Task returns the state the queued changes leave a task in, starting from the server copy of its
+// first queued change. It reports false when no change to the task is queued or it is deleted.
+func (j *Journal) Task(taskID string) (*types.Task, bool) {
+	entries, err := j.Entries()
+	if err != nil {
+		return nil, false
+	}
+	var t *types.Task
+	for _, e := range entries {
+		if e.TaskID != taskID {
+			continue
+		}
+		if t == nil && e.Base != nil {
+			t = e.Base
+		}
+		switch e.Op {
+		case OpCreate, OpUpdate:
+			t = e.Task
+		case OpComplete:
+			if t != nil {
+				completed := *t
+				completed.Status = task.StatusComplete
+				t = &completed
+			}
+		case OpDelete:
+			t = nil
+		}
+	}
+	return t, t != nil
+}
@@ -0,0 +1,256 @@
+package mdsync
+
+import (
+	"fmt"
+	"github.com/sho0pi/tickli/internal/export"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/types/task"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	checklistLine = regexp.MustCompile(`^[-*+] \[([ xX])\](?: (.*))?$`)
+	idComment     = regexp.MustCompile(`\s*<!--\s*tickli:(\S+)\s*-->\s*$`)
+)
+
+// Item is a task as written on a checklist line of the file
+type Item struct {
+	ID       string        `json:"id"`
+	Done     bool          `json:"done"`
+	Title    string        `json:"title"`
+	Priority task.Priority `json:"priority"`
+	// Start and Due are dates in the export.MarkdownDateLayout format, empty when unset
+	Start string   `json:"start,omitempty"`
+	Due   string   `json:"due,omitempty"`
+	Tags  []string `json:"tags,omitempty"`
+}
+
+// Line is a line of the file, Item is set for top level checklist lines
+type Line struct {
+	Text string
+	Item *Item
+}
+
+// Document is a Markdown file, everything but its top level checklist lines is kept as is
+type Document struct {
+	Lines []Line
+}
+
+// Parse splits the Markdown text in lines, parsing the checklist ones
+func Parse(text string) *Document {
+	doc := &Document{}
+	text = strings.TrimSuffix(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	if text == "" {
+		return doc
+	}
+	for _, line := range strings.Split(text, "\n") {
+		item, ok := ParseItem(line)
+		if ok {
+			doc.Lines = append(doc.Lines, Line{Item: item})
+		} else {
+			doc.Lines = append(doc.Lines, Line{Text: line})
+		}
+	}
+	return doc
+}
+
+// String formats the document, items are written in their canonical form
+func (d *Document) String() string {
+	var sb strings.Builder
+	for _, line := range d.Lines {
+		if line.Item != nil {
+			sb.WriteString(line.Item.String())
+		} else {
+			sb.WriteString(line.Text)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// Items returns the checklist items in the order of the file
+func (d *Document) Items() []*Item {
+	var items []*Item
+	for _, line := range d.Lines {
+		if line.Item != nil {
+			items = append(items, line.Item)
+		}
+	}
+	return items
+}
+
+// Append adds the item after the last checklist line, or at the end of a file without any
+func (d *Document) Append(item Item) {
+	last := -1
+	for i, line := range d.Lines {
+		if line.Item != nil {
+			last = i
+		}
+	}
+	if last == -1 {
+		if n := len(d.Lines); n > 0 && strings.TrimSpace(d.Lines[n-1].Text) != "" {
+			d.Lines = append(d.Lines, Line{})
+		}
+		d.Lines = append(d.Lines, Line{Item: &item})
+		return
+	}
+	d.Lines = slices.Insert(d.Lines, last+1, Line{Item: &item})
+}
+
+// Remove drops the line of the item
+func (d *Document) Remove(item *Item) {
+	d.Lines = slices.DeleteFunc(d.Lines, func(line Line) bool {
+		return line.Item == item
+	})
+}
+
+// ParseItem parses a top level checklist line, dates, priorities and tags use the
+// Obsidian Tasks emoji conventions of 'tickli project export'
+func ParseItem(line string) (*Item, bool) {
+	match := checklistLine.FindStringSubmatch(strings.TrimRight(line, " \t"))
+	if match == nil {
+		return nil, false
+	}
+	item := &Item{Done: match[1] != " "}
+	body := match[2]
+	if id := idComment.FindStringSubmatch(body); id != nil {
+		item.ID = id[1]
+		body = body[:len(body)-len(id[0])]
+	}
+
+	var title []string
+	fields := strings.Fields(body)
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		hasDate := i+1 < len(fields) && isDate(fields[i+1])
+		switch {
+		case field == export.MarkdownStart && hasDate:
+			i++
+			item.Start = fields[i]
+		case field == export.MarkdownDue && hasDate:
+			i++
+			item.Due = fields[i]
+		case field == export.MarkdownDone && hasDate:
+			// The completion date is set by the server
+			i++
+		case priorityOf(field) != task.PriorityNone:
+			item.Priority = priorityOf(field)
+		case len(field) > 1 && strings.HasPrefix(field, "#"):
+			item.Tags = append(item.Tags, field[1:])
+		default:
+			title = append(title, field)
+		}
+	}
+	item.Title = strings.Join(title, " ")
+	return item, true
+}
+
+// String formats the item as a checklist line, its ID in a trailing HTML comment
+func (i Item) String() string {
+	var sb strings.Builder
+	if i.Done {
+		sb.WriteString("- [x] ")
+	} else {
+		sb.WriteString("- [ ] ")
+	}
+	sb.WriteString(i.Title)
+	if emoji, ok := export.MarkdownPriority[i.Priority]; ok {
+		sb.WriteString(" " + emoji)
+	}
+	if i.Start != "" {
+		fmt.Fprintf(&sb, " %s %s", export.MarkdownStart, i.Start)
+	}
+	if i.Due != "" {
+		fmt.Fprintf(&sb, " %s %s", export.MarkdownDue, i.Due)
+	}
+	for _, tag := range i.Tags {
+		sb.WriteString(" #" + tag)
+	}
+	if i.ID != "" {
+		fmt.Fprintf(&sb, " <!-- tickli:%s -->", i.ID)
+	}
+	return sb.String()
+}
+
+// ItemFromTask returns the checklist item of a task, dates in the task time zone
+func ItemFromTask(t types.Task) Item {
+	item := Item{
+		ID:       t.ID,
+		Done:     t.Status == task.StatusComplete,
+		Title:    t.Title,
+		Priority: t.Priority,
+	}
+	if !t.StartDate.IsZero() {
+		item.Start = export.LocalTime(t.StartDate, t.TimeZone).Format(export.MarkdownDateLayout)
+	}
+	if !t.DueDate.IsZero() {
+		item.Due = export.LocalTime(t.DueDate, t.TimeZone).Format(export.MarkdownDateLayout)
+	}
+	for _, tag := range t.Tags {
+		item.Tags = append(item.Tags, strings.ReplaceAll(tag, " ", "-"))
+	}
+	return item
+}
+
+// Apply writes the fields of the item to the task. Dates whose day is unchanged keep their time,
+// changed dates become all-day dates.
+func (i Item) Apply(t *types.Task) error {
+	current := ItemFromTask(*t)
+	t.Title = i.Title
+	t.Priority = i.Priority
+	if !slices.Equal(i.Tags, current.Tags) {
+		t.Tags = slices.Clone(i.Tags)
+	}
+
+	loc := time.Local
+	if t.TimeZone != "" {
+		if l, err := time.LoadLocation(t.TimeZone); err == nil {
+			loc = l
+		}
+	}
+	for _, date := range []struct {
+		value, current string
+		field          *types.TickTickTime
+	}{
+		{i.Start, current.Start, &t.StartDate},
+		{i.Due, current.Due, &t.DueDate},
+	} {
+		if date.value == date.current {
+			continue
+		}
+		if date.value == "" {
+			*date.field = types.TickTickTime{}
+			continue
+		}
+		day, err := time.ParseInLocation(export.MarkdownDateLayout, date.value, loc)
+		if err != nil {
+			return fmt.Errorf("invalid date %q of %q", date.value, i.Title)
+		}
+		*date.field = types.TickTickTime(day)
+		t.IsAllDay = true
+	}
+	return nil
+}
+
+// sameFields compares the task fields of the items, ignoring their ID and status
+func (i Item) sameFields(other Item) bool {
+	return i.Title == other.Title && i.Priority == other.Priority &&
+		i.Start == other.Start && i.Due == other.Due && slices.Equal(i.Tags, other.Tags)
+}
+
+func priorityOf(emoji string) task.Priority {
+	for p, e := range export.MarkdownPriority {
+		if e == emoji {
+			return p
+		}
+	}
+	return task.PriorityNone
+}
+
+func isDate(s string) bool {
+	_, err := time.Parse(export.MarkdownDateLayout, s)
+	return err == nil
+}
@@ -0,0 +1,80 @@
+package mdsync
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"os"
+	"path/filepath"
+)
+
+// State is what the file and the project agreed on after the last sync, the base of the
+// three-way merge telling which side changed an item
+type State struct {
+	ProjectID string          `json:"project_id"`
+	Items     map[string]Item `json:"items"`
+}
+
+// StatePath returns where the state of syncing the file is kept, in dir and keyed by the absolute file path
+func StatePath(dir, file string) (string, error) {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return "", errors.Wrap(err, "resolving file path")
+	}
+	sum := sha256.Sum256([]byte(abs))
+	return filepath.Join(dir, "sync-md", fmt.Sprintf("%x.json", sum[:8])), nil
+}
+
+// LoadState reads the state of the last sync, a missing state is empty
+func LoadState(path string) (*State, error) {
+	state := &State{Items: make(map[string]Item)}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "reading sync state")
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, errors.Wrap(err, "decoding sync state")
+	}
+	if state.Items == nil {
+		state.Items = make(map[string]Item)
+	}
+	return state, nil
+}
+
+// Save writes the state
+func (s *State) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "encoding sync state")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return errors.Wrap(err, "creating sync state directory")
+	}
+	return writeFile(path, data, 0600)
+}
+
+// writeFile replaces the file atomically, so an editor never reads a partial file
+func writeFile(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tickli-*")
+	if err != nil {
+		return errors.Wrapf(err, "writing %s", path)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return errors.Wrapf(err, "writing %s", path)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return errors.Wrapf(err, "writing %s", path)
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		os.Remove(tmp.Name())
+		return errors.Wrapf(err, "writing %s", path)
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,320 @@
+package mdsync
+
+import (
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/types/task"
+	"os"
+	"slices"
+)
+
+type Action string
+
+const (
+	ActionCreated   Action = "created"
+	ActionUpdated   Action = "updated"
+	ActionCompleted Action = "completed"
+	ActionReopened  Action = "reopened"
+	ActionDeleted   Action = "deleted"
+	ActionAdded     Action = "added"
+	ActionRemoved   Action = "removed"
+	ActionConflict  Action = "conflict"
+)
+
+// Target is the side a change was made to
+type Target string
+
+const (
+	TargetFile     Target = "file"
+	TargetTickTick Target = "ticktick"
+)
+
+// Change is a change made to the file or the project while syncing them
+type Change struct {
+	Action Action
+	Target Target
+	Item   Item
+	// Fields lists the fields changed on both sides for conflicts
+	Fields []string
+	Err    error
+}
+
+type Options struct {
+	// AllowDelete deletes the tasks whose line was removed from the file, their line is added back otherwise
+	AllowDelete bool
+	DryRun      bool
+}
+
+// Syncer keeps a Markdown checklist file and a project in sync
+type Syncer struct {
+	Client    *api.Client
+	Project   types.Project
+	Path      string
+	StatePath string
+	Options
+}
+
+type syncRun struct {
+	*Syncer
+	// client acts on the live server state, queueing changes offline would have them sent again
+	// by every sync as their line never gets an ID
+	client *api.Client
+	state  *State
+	doc    *Document
+	report func(Change)
+	// retry holds the base to keep for items whose change could not be sent, so it is sent again
+	retry map[string]Item
+}
+
+// Sync merges the file and the project. Items are matched by the task ID of their line, each
+// field taking the side that changed it since the last sync, the server when both did.
+// Lines without an ID create tasks, and open tasks missing from the file are added to it.
+func (s *Syncer) Sync(report func(Change)) error {
+	text, err := os.ReadFile(s.Path)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "reading file")
+	}
+	perm := os.FileMode(0644)
+	if info, err := os.Stat(s.Path); err == nil {
+		perm = info.Mode().Perm()
+	}
+	doc := Parse(string(text))
+	if len(text) == 0 {
+		doc = Parse("# " + s.Project.Name + "\n")
+	}
+
+	state, err := LoadState(s.StatePath)
+	if err != nil {
+		return err
+	}
+	if state.ProjectID != s.Project.ID {
+		state = &State{ProjectID: s.Project.ID, Items: make(map[string]Item)}
+	}
+
+	client := s.Client.WithLive()
+	data, err := client.GetProjectWithTasks(s.Project.ID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get project tasks")
+	}
+	remote := make(map[string]types.Task, len(data.Tasks))
+	for _, t := range data.Tasks {
+		remote[t.ID] = t
+	}
+
+	run := &syncRun{Syncer: s, client: client, state: state, doc: doc, report: report, retry: make(map[string]Item)}
+	seen := make(map[string]bool)
+	for _, item := range doc.Items() {
+		if item.ID == "" {
+			run.create(item)
+			continue
+		}
+		seen[item.ID] = true
+		if t, ok := remote[item.ID]; ok {
+			run.merge(item, t)
+		} else if err := run.closed(item); err != nil {
+			return err
+		}
+	}
+	for _, t := range data.Tasks {
+		if !seen[t.ID] {
+			run.missing(t)
+		}
+	}
+
+	if s.DryRun {
+		return nil
+	}
+	if out := doc.String(); out != string(text) {
+		if err := writeFile(s.Path, []byte(out), perm); err != nil {
+			return err
+		}
+	}
+	items := make(map[string]Item)
+	for _, item := range doc.Items() {
+		if item.ID == "" {
+			continue
+		}
+		if base, ok := run.retry[item.ID]; ok {
+			items[item.ID] = base
+		} else {
+			items[item.ID] = *item
+		}
+	}
+	state.Items = items
+	return state.Save(s.StatePath)
+}
+
+// create adds the task of a line without ID, completing it right away when checked
+func (r *syncRun) create(item *Item) {
+	if item.Title == "" {
+		return
+	}
+	if r.DryRun {
+		r.report(Change{Action: ActionCreated, Target: TargetTickTick, Item: *item})
+		return
+	}
+	t := &types.Task{ProjectID: r.Project.ID}
+	if err := item.Apply(t); err != nil {
+		r.report(Change{Action: ActionCreated, Target: TargetTickTick, Item: *item, Err: err})
+		return
+	}
+	created, err := r.client.CreateTask(t)
+	if err != nil {
+		r.report(Change{Action: ActionCreated, Target: TargetTickTick, Item: *item, Err: err})
+		return
+	}
+	item.ID = created.ID
+	r.report(Change{Action: ActionCreated, Target: TargetTickTick, Item: *item})
+	if item.Done {
+		err := r.client.CompleteTask(created.ProjectID, created.ID)
+		r.report(Change{Action: ActionCompleted, Target: TargetTickTick, Item: *item, Err: err})
+		if err != nil {
+			open := *item
+			open.Done = false
+			r.retry[item.ID] = open
+		}
+	}
+}
+
+// merge reconciles the line of an open task with the task
+func (r *syncRun) merge(item *Item, t types.Task) {
+	current := ItemFromTask(t)
+	base, ok := r.state.Items[item.ID]
+	if !ok {
+		// Never synced from this file, the server is the reference
+		base = *item
+	}
+	// A failed change is sent again by the next sync as long as the server keeps its value
+	retryBase := base
+	if !ok {
+		retryBase = current
+	}
+	merged, conflicts := mergeItems(base, *item, current)
+	if len(conflicts) > 0 {
+		r.report(Change{Action: ActionConflict, Target: TargetFile, Item: merged, Fields: conflicts})
+	}
+
+	if !merged.sameFields(current) {
+		err := r.update(merged, t)
+		r.report(Change{Action: ActionUpdated, Target: TargetTickTick, Item: merged, Err: err})
+		if err != nil {
+			r.retry[item.ID] = retryBase
+			return
+		}
+	}
+	if merged.Done && !current.Done {
+		var err error
+		if !r.DryRun {
+			err = r.client.CompleteTask(t.ProjectID, t.ID)
+		}
+		r.report(Change{Action: ActionCompleted, Target: TargetTickTick, Item: merged, Err: err})
+		if err != nil {
+			r.retry[item.ID] = retryBase
+			return
+		}
+	}
+	if !merged.sameFields(*item) || merged.Done != item.Done {
+		r.report(Change{Action: ActionUpdated, Target: TargetFile, Item: merged})
+	}
+	*item = merged
+}
+
+func (r *syncRun) update(item Item, t types.Task) error {
+	if err := item.Apply(&t); err != nil {
+		return err
+	}
+	if r.DryRun {
+		return nil
+	}
+	_, err := r.client.UpdateTask(&t)
+	return err
+}
+
+// closed handles a line whose task is no longer open, it was either completed or deleted
+func (r *syncRun) closed(item *Item) error {
+	base, known := r.state.Items[item.ID]
+	if known && base.Done && item.Done {
+		return nil
+	}
+	t, err := r.client.GetTask(r.Project.ID, item.ID)
+	if err != nil && api.IsNetworkError(err) {
+		return errors.Wrap(err, "failed to get task")
+	}
+	if err != nil || t.ID != item.ID {
+		if !known {
+			// Not a task of this project, leave the line alone
+			return nil
+		}
+		r.report(Change{Action: ActionRemoved, Target: TargetFile, Item: *item})
+		r.doc.Remove(item)
+		return nil
+	}
+	if t.Status != task.StatusComplete || item.Done {
+		return nil
+	}
+
+	if known && base.Done {
+		// Unchecked in the file
+		t.Status = task.StatusNormal
+		if !r.DryRun {
+			_, err = r.client.UpdateTask(t)
+		}
+		r.report(Change{Action: ActionReopened, Target: TargetTickTick, Item: *item, Err: err})
+		if err != nil {
+			r.retry[item.ID] = base
+		}
+		return nil
+	}
+	item.Done = true
+	r.report(Change{Action: ActionCompleted, Target: TargetFile, Item: *item})
+	return nil
+}
+
+// missing handles an open task without a line, a new task or one whose line was removed
+func (r *syncRun) missing(t types.Task) {
+	item := ItemFromTask(t)
+	if _, known := r.state.Items[t.ID]; !known || !r.AllowDelete {
+		r.doc.Append(item)
+		r.report(Change{Action: ActionAdded, Target: TargetFile, Item: item})
+		return
+	}
+	var err error
+	if !r.DryRun {
+		err = r.client.DeleteTask(t.ProjectID, t.ID)
+	}
+	r.report(Change{Action: ActionDeleted, Target: TargetTickTick, Item: item, Err: err})
+	if err != nil {
+		r.doc.Append(item)
+	}
+}
+
+// mergeItems is a three-way merge of the fields of an item, each field taking the side that
+// changed it since base. Fields changed on both sides take the remote value and are returned.
+func mergeItems(base, local, remote Item) (Item, []string) {
+	var conflicts []string
+	merged := remote
+	merged.Done = mergeField("done", base.Done, local.Done, remote.Done, equal, &conflicts)
+	merged.Title = mergeField("title", base.Title, local.Title, remote.Title, equal, &conflicts)
+	merged.Priority = mergeField("priority", base.Priority, local.Priority, remote.Priority, equal, &conflicts)
+	merged.Start = mergeField("start", base.Start, local.Start, remote.Start, equal, &conflicts)
+	merged.Due = mergeField("due", base.Due, local.Due, remote.Due, equal, &conflicts)
+	merged.Tags = mergeField("tags", base.Tags, local.Tags, remote.Tags, slices.Equal, &conflicts)
+	return merged, conflicts
+}
+
+func mergeField[T any](name string, base, local, remote T, eq func(a, b T) bool, conflicts *[]string) T {
+	switch {
+	case eq(local, remote), eq(local, base):
+		return remote
+	case eq(remote, base):
+		return local
+	default:
+		*conflicts = append(*conflicts, name)
+		return remote
+	}
+}
+
+func equal[T comparable](a, b T) bool {
+	return a == b
+}
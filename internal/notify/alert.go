@@ -0,0 +1,86 @@
+package notify
+
+import (
+	"github.com/sho0pi/tickli/internal/export"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/types/task"
+	"slices"
+	"time"
+)
+
+// Alert is a reminder of a task firing at a given time
+type Alert struct {
+	TaskID    string        `json:"taskId"`
+	ProjectID string        `json:"projectId"`
+	Project   string        `json:"project"`
+	Title     string        `json:"title"`
+	Priority  task.Priority `json:"priority"`
+	// Time is the task time the reminder is relative to
+	Time     time.Time `json:"time"`
+	IsAllDay bool      `json:"isAllDay"`
+	Reminder string    `json:"reminder"`
+	At       time.Time `json:"at"`
+}
+
+// Key identifies the alert, a rescheduled task getting new keys for its reminders
+func (a Alert) Key() string {
+	return a.TaskID + "|" + a.Reminder + "|" + a.At.UTC().Format(time.RFC3339)
+}
+
+// Alerts returns the reminders of open tasks firing in the (from, to] window, soonest first.
+// Reminders are relative to the start of the task, or its due date when it has no start,
+// which is the start of the day in the task time zone for all-day tasks.
+func Alerts(projects []types.ProjectData, from, to time.Time) []Alert {
+	var alerts []Alert
+	for _, data := range projects {
+		for _, t := range data.Tasks {
+			if t.Status == task.StatusComplete || len(t.Reminders) == 0 {
+				continue
+			}
+			anchor, ok := taskTime(t)
+			if !ok {
+				continue
+			}
+			for _, reminder := range t.Reminders {
+				offset, err := types.ReminderOffset(reminder)
+				if err != nil {
+					continue
+				}
+				at := anchor.Add(offset)
+				if !at.After(from) || at.After(to) {
+					continue
+				}
+				alerts = append(alerts, Alert{
+					TaskID:    t.ID,
+					ProjectID: t.ProjectID,
+					Project:   data.Project.Name,
+					Title:     t.Title,
+					Priority:  t.Priority,
+					Time:      anchor,
+					IsAllDay:  t.IsAllDay,
+					Reminder:  types.ReminderTrigger(reminder),
+					At:        at,
+				})
+			}
+		}
+	}
+	slices.SortStableFunc(alerts, func(a, b Alert) int {
+		return a.At.Compare(b.At)
+	})
+	return alerts
+}
+
+func taskTime(t types.Task) (time.Time, bool) {
+	ts := t.StartDate
+	if ts.IsZero() {
+		ts = t.DueDate
+	}
+	if ts.IsZero() {
+		return time.Time{}, false
+	}
+	local := export.LocalTime(ts, t.TimeZone)
+	if t.IsAllDay {
+		local = time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+	}
+	return local, true
+}
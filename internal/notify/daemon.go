@@ -0,0 +1,123 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/api"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// firedRetention is how long fired alerts are remembered, longer than any catch-up window
+const firedRetention = 7 * 24 * time.Hour
+
+// Fired records the alerts already delivered, keyed by Alert.Key, so restarts don't repeat them
+type Fired struct {
+	path   string
+	Alerts map[string]time.Time `json:"alerts"`
+}
+
+// LoadFired reads the fired alerts kept at path, a missing file has none
+func LoadFired(path string) (*Fired, error) {
+	f := &Fired{path: path, Alerts: make(map[string]time.Time)}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "reading fired alerts")
+	}
+	if err := json.Unmarshal(data, f); err != nil {
+		return nil, errors.Wrap(err, "decoding fired alerts")
+	}
+	if f.Alerts == nil {
+		f.Alerts = make(map[string]time.Time)
+	}
+	return f, nil
+}
+
+// Save writes the fired alerts, forgetting the ones older than the retention
+func (f *Fired) Save() error {
+	for key, at := range f.Alerts {
+		if time.Since(at) > firedRetention {
+			delete(f.Alerts, key)
+		}
+	}
+	data, err := json.Marshal(f)
+	if err != nil {
+		return errors.Wrap(err, "encoding fired alerts")
+	}
+	if err := os.MkdirAll(filepath.Dir(f.path), 0700); err != nil {
+		return errors.Wrap(err, "creating state directory")
+	}
+	tmp := f.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return errors.Wrap(err, "writing fired alerts")
+	}
+	return os.Rename(tmp, f.path)
+}
+
+// Daemon fires the reminders of the tasks of a client
+type Daemon struct {
+	Client     *api.Client
+	ProjectIDs []string
+	Notifiers  []Notifier
+	Fired      *Fired
+	// CatchUp fires reminders missed by up to this long, while the daemon or the machine was down
+	CatchUp time.Duration
+	// OnError is called for fetch and notifier failures, the daemon keeps running
+	OnError func(error)
+}
+
+// Check fires the alerts due since the catch-up window that haven't fired yet. Projects are fetched
+// past the cache so reminders set moments ago are seen. An alert only counts as fired once a
+// notifier delivered it, failed ones are retried by the next check while in the catch-up window.
+func (d *Daemon) Check(ctx context.Context, now time.Time) error {
+	projects, err := d.Client.WithRefresh().ListProjectsData(d.ProjectIDs...)
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch projects")
+	}
+
+	fired := false
+	for _, a := range Alerts(projects, now.Add(-d.CatchUp), now) {
+		if _, ok := d.Fired.Alerts[a.Key()]; ok {
+			continue
+		}
+		delivered := false
+		for _, n := range d.Notifiers {
+			err := n.Notify(ctx, a)
+			if err == nil {
+				delivered = true
+			} else if d.OnError != nil {
+				d.OnError(err)
+			}
+		}
+		if !delivered {
+			continue
+		}
+		d.Fired.Alerts[a.Key()] = now
+		fired = true
+	}
+	if !fired {
+		return nil
+	}
+	return d.Fired.Save()
+}
+
+// Run checks every interval until the context is done
+func (d *Daemon) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := d.Check(ctx, time.Now()); err != nil && d.OnError != nil {
+			d.OnError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
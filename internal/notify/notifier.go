@@ -0,0 +1,119 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/types/task"
+	"github.com/sho0pi/tickli/internal/utils"
+	"github.com/spf13/cobra"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Notifier delivers alerts
+type Notifier interface {
+	Notify(ctx context.Context, a Alert) error
+}
+
+// Kind is a notifier the daemon can use
+type Kind string
+
+const (
+	KindDesktop Kind = "desktop"
+	KindBell    Kind = "bell"
+	KindExec    Kind = "exec"
+)
+
+var KindCompletion = []cobra.Completion{
+	cobra.CompletionWithDesc(string(KindDesktop), "Desktop notification through notify-send (libnotify)"),
+	cobra.CompletionWithDesc(string(KindBell), "Terminal bell and a line on stdout"),
+	cobra.CompletionWithDesc(string(KindExec), "Run the --exec command"),
+}
+
+var KindCompletionFunc = cobra.FixedCompletions(KindCompletion, cobra.ShellCompDirectiveNoFileComp)
+
+// ParseKind validates a notifier name
+func ParseKind(value string) (Kind, error) {
+	switch Kind(value) {
+	case KindDesktop, KindBell, KindExec:
+		return Kind(value), nil
+	default:
+		return "", fmt.Errorf("invalid notifier: %s", value)
+	}
+}
+
+// Summary is the one line description of an alert
+func (a Alert) Summary() string {
+	if a.IsAllDay {
+		return fmt.Sprintf("%s (%s)", a.Title, a.Time.Format("Mon Jan 2"))
+	}
+	return fmt.Sprintf("%s (%s)", a.Title, a.Time.Local().Format("Mon Jan 2 15:04"))
+}
+
+// Desktop shows alerts with notify-send
+type Desktop struct{}
+
+func (Desktop) Notify(ctx context.Context, a Alert) error {
+	urgency := "normal"
+	if a.Priority == task.PriorityHigh {
+		urgency = "critical"
+	}
+	body := a.Time.Local().Format("Mon Jan 2 15:04")
+	if a.IsAllDay {
+		body = a.Time.Format("Mon Jan 2")
+	}
+	if a.Project != "" {
+		body += " · " + a.Project
+	}
+	cmd := exec.CommandContext(ctx, "notify-send", "--app-name=tickli", "--urgency="+urgency, a.Title, body)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "notify-send: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// Bell rings the terminal bell and prints the alert
+type Bell struct {
+	W io.Writer
+}
+
+func (b Bell) Notify(_ context.Context, a Alert) error {
+	_, err := fmt.Fprintf(b.W, "\a⏰ %s\n", a.Summary())
+	return err
+}
+
+// Exec runs a shell command for each alert, with the alert as JSON on its stdin and
+// its fields in TICKLI_* environment variables
+type Exec struct {
+	Command        string
+	Stdout, Stderr io.Writer
+}
+
+func (e Exec) Notify(ctx context.Context, a Alert) error {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return errors.Wrap(err, "encoding alert")
+	}
+	cmd := utils.ShellCommand(ctx, e.Command)
+	cmd.Stdin = strings.NewReader(string(data) + "\n")
+	cmd.Stdout, cmd.Stderr = e.Stdout, e.Stderr
+	cmd.Env = append(os.Environ(),
+		"TICKLI_TASK_ID="+a.TaskID,
+		"TICKLI_TASK_TITLE="+a.Title,
+		"TICKLI_PROJECT_ID="+a.ProjectID,
+		"TICKLI_PROJECT="+a.Project,
+		fmt.Sprintf("TICKLI_TASK_PRIORITY=%d", a.Priority),
+		"TICKLI_REMINDER="+a.Reminder,
+		"TICKLI_ALERT_AT="+a.At.Format(time.RFC3339),
+		"TICKLI_TASK_TIME="+a.Time.Format(time.RFC3339),
+	)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "exec %q", e.Command)
+	}
+	return nil
+}
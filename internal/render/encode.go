@@ -0,0 +1,137 @@
+package render
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/types"
+	"gopkg.in/yaml.v3"
+	"io"
+	"text/template"
+)
+
+func writeJSON(w io.Writer, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal output")
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+func writeNDJSON(w io.Writer, values []any) error {
+	enc := json.NewEncoder(w)
+	for _, v := range values {
+		if err := enc.Encode(v); err != nil {
+			return errors.Wrap(err, "failed to marshal output")
+		}
+	}
+	return nil
+}
+
+// writeYAML goes through JSON so YAML keys and values match the JSON output
+func writeYAML(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal output")
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return errors.Wrap(err, "failed to convert output to yaml")
+	}
+	resetStyle(&node)
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return errors.Wrap(err, "failed to write yaml")
+	}
+	return enc.Close()
+}
+
+// resetStyle drops the flow style decoded from JSON, so the YAML is written in block style
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
+
+func writeDelimited(w io.Writer, format types.OutputFormat, header []string, rows [][]string) error {
+	cw := csv.NewWriter(w)
+	if format == types.OutputTSV {
+		cw.Comma = '\t'
+	}
+	if err := cw.Write(header); err != nil {
+		return errors.Wrap(err, "failed to write header")
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return errors.Wrap(err, "failed to write rows")
+	}
+	return nil
+}
+
+// executeTemplate runs the template for a single value, terminating its output with a newline
+func executeTemplate(w io.Writer, tmpl *template.Template, v any) error {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, v); err != nil {
+		return errors.Wrap(err, "failed to execute template")
+	}
+	if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// record is an ordered set of selected field values
+type record []fieldValue
+
+type fieldValue struct {
+	name  string
+	value any
+}
+
+func newRecord[T any](v T, fields []Field[T]) record {
+	r := make(record, len(fields))
+	for i, f := range fields {
+		r[i] = fieldValue{name: f.Name, value: f.Value(v)}
+	}
+	return r
+}
+
+func (r record) texts() []string {
+	texts := make([]string, len(r))
+	for i, f := range r {
+		texts[i] = Text(f.value)
+	}
+	return texts
+}
+
+func (r record) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range r {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(f.name)
+		if err != nil {
+			return nil, err
+		}
+		if t, ok := f.value.(types.TickTickTime); ok && t.IsZero() {
+			f.value = nil
+		}
+		value, err := json.Marshal(f.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
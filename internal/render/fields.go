@@ -0,0 +1,109 @@
+package render
+
+import (
+	"fmt"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/types/task"
+	"strings"
+)
+
+// Field is a named, selectable attribute of a rendered record. Fields are named like the JSON
+// keys of the record, so every output format uses the same names.
+type Field[T any] struct {
+	Name  string
+	Value func(T) any
+}
+
+var TaskFields = []Field[types.Task]{
+	{"id", func(t types.Task) any { return t.ID }},
+	{"projectId", func(t types.Task) any { return t.ProjectID }},
+	{"title", func(t types.Task) any { return t.Title }},
+	{"content", func(t types.Task) any { return t.Content }},
+	{"desc", func(t types.Task) any { return t.Desc }},
+	{"status", func(t types.Task) any { return t.Status }},
+	{"priority", func(t types.Task) any { return t.Priority }},
+	{"tags", func(t types.Task) any { return t.Tags }},
+	{"startDate", func(t types.Task) any { return t.StartDate }},
+	{"dueDate", func(t types.Task) any { return t.DueDate }},
+	{"completedTime", func(t types.Task) any { return t.CompletedTime }},
+	{"isAllDay", func(t types.Task) any { return t.IsAllDay }},
+	{"timeZone", func(t types.Task) any { return t.TimeZone }},
+	{"repeatFlag", func(t types.Task) any { return t.RepeatFlag }},
+	{"reminders", func(t types.Task) any { return t.Reminders }},
+	{"items", func(t types.Task) any { return t.Items }},
+}
+
+var ProjectFields = []Field[types.Project]{
+	{"id", func(p types.Project) any { return p.ID }},
+	{"name", func(p types.Project) any { return p.Name }},
+	{"color", func(p types.Project) any { return p.Color }},
+	{"closed", func(p types.Project) any { return p.Closed }},
+	{"groupId", func(p types.Project) any { return p.GroupID }},
+	{"viewMode", func(p types.Project) any { return p.ViewMode }},
+	{"kind", func(p types.Project) any { return p.Kind }},
+	{"permission", func(p types.Project) any { return p.Permission }},
+}
+
+var ItemFields = []Field[types.ChecklistItem]{
+	{"id", func(i types.ChecklistItem) any { return i.ID }},
+	{"title", func(i types.ChecklistItem) any { return i.Title }},
+	{"status", func(i types.ChecklistItem) any {
+		if i.IsCompleted() {
+			return task.StatusComplete.Name()
+		}
+		return task.StatusNormal.Name()
+	}},
+	{"startDate", func(i types.ChecklistItem) any { return i.StartDate }},
+	{"isAllDay", func(i types.ChecklistItem) any { return i.IsAllDay }},
+	{"timeZone", func(i types.ChecklistItem) any { return i.TimeZone }},
+}
+
+// Default columns of the table format when no fields are selected
+var (
+	taskColumns    = []string{"id", "title", "status", "priority", "dueDate", "tags"}
+	projectColumns = []string{"id", "name", "kind", "viewMode", "closed"}
+	itemColumns    = []string{"id", "title", "status", "startDate"}
+)
+
+// fieldAliases are short names accepted by --fields for the fields named after JSON keys
+var fieldAliases = map[string]string{
+	"project":   "projectId",
+	"start":     "startDate",
+	"due":       "dueDate",
+	"completed": "completedTime",
+	"allDay":    "isAllDay",
+	"repeat":    "repeatFlag",
+	"group":     "groupId",
+}
+
+func selectFields[T any](available []Field[T], names []string) ([]Field[T], error) {
+	var selected []Field[T]
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		for alias, field := range fieldAliases {
+			if strings.EqualFold(alias, name) {
+				name = field
+			}
+		}
+		found := false
+		for _, f := range available {
+			if strings.EqualFold(f.Name, name) {
+				selected = append(selected, f)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown field %q (available: %s)", name, fieldNames(available))
+		}
+	}
+	return selected, nil
+}
+
+func fieldNames[T any](fields []Field[T]) string {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.Name
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,282 @@
+package render
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/types/project"
+	"github.com/sho0pi/tickli/internal/types/task"
+	"github.com/sho0pi/tickli/internal/utils"
+	"github.com/spf13/cobra"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// Options holds the output flags shared by every command that prints tasks, projects or checklist items
+type Options struct {
+	Output       types.OutputFormat
+	TemplateFile string
+	Fields       []string
+}
+
+func AddFlags(cmd *cobra.Command, opts *Options) {
+	cmd.Flags().VarP(&opts.Output, "output", "o", "Display format: simple, json, table, yaml, csv, tsv, ndjson or template=<go-template>")
+	_ = cmd.RegisterFlagCompletionFunc("output", types.OutputFormatCompletionFunc)
+	cmd.Flags().StringVar(&opts.TemplateFile, "template-file", "", "Render the output with the Go template in this file")
+	cmd.Flags().StringSliceVar(&opts.Fields, "fields", nil, "Only output the given fields, named like the JSON keys (e.g., 'id,title,dueDate')")
+	cmd.MarkFlagsMutuallyExclusive("template-file", "fields")
+}
+
+// IsSet reports whether any output option was explicitly given
+func (o *Options) IsSet() bool {
+	return o.Output != "" || o.TemplateFile != "" || len(o.Fields) > 0
+}
+
+// IsDetail reports whether the detailed simple output is used, without selected fields
+func (o *Options) IsDetail() bool {
+	format, err := o.format()
+	return err == nil && format == types.OutputSimple && len(o.Fields) == 0
+}
+
+func (o *Options) format() (types.OutputFormat, error) {
+	if o.TemplateFile != "" {
+		if o.Output != "" && o.Output.Kind() != types.OutputTemplate {
+			return "", fmt.Errorf("--template-file cannot be used with output format %s", o.Output)
+		}
+		return types.OutputTemplate, nil
+	}
+	if o.Output == "" {
+		return types.OutputSimple, nil
+	}
+	if o.Output.Kind() == types.OutputTemplate && len(o.Fields) > 0 {
+		return "", errors.New("--fields cannot be used with template output")
+	}
+	return o.Output.Kind(), nil
+}
+
+func (o *Options) template() (*template.Template, error) {
+	text := o.Output.Arg()
+	if o.TemplateFile != "" {
+		data, err := os.ReadFile(o.TemplateFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read template file")
+		}
+		text = string(data)
+	}
+	if text == "" {
+		return nil, errors.New("template output requires a template, e.g. -o 'template={{.Title}}' or --template-file")
+	}
+	tmpl, err := template.New("output").Funcs(FuncMap()).Parse(text)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse template")
+	}
+	return tmpl, nil
+}
+
+// schema describes how records of a type are laid out
+type schema[T any] struct {
+	fields []Field[T]
+	// columns are the default fields of the table format
+	columns []string
+	// line is the simple format of a record within a list
+	line func(T) string
+}
+
+var taskSchema = schema[types.Task]{
+	fields:  TaskFields,
+	columns: taskColumns,
+	line: func(t types.Task) string {
+		return fmt.Sprintf("%s %s %s (%s)", t.Status, t.Priority, t.Title, t.ID)
+	},
+}
+
+var projectSchema = schema[types.Project]{
+	fields:  ProjectFields,
+	columns: projectColumns,
+	line: func(p types.Project) string {
+		return fmt.Sprintf("(%s) %s", p.ID, p.Name)
+	},
+}
+
+var itemSchema = schema[types.ChecklistItem]{
+	fields:  ItemFields,
+	columns: itemColumns,
+	line: func(i types.ChecklistItem) string {
+		status := task.StatusNormal
+		if i.IsCompleted() {
+			status = task.StatusComplete
+		}
+		return fmt.Sprintf("%s %s (%s)", status, i.Title, i.ID)
+	},
+}
+
+// Task renders a single task, the simple format being the detailed task description
+func Task(w io.Writer, t types.Task, projectColor project.Color, opts *Options) error {
+	return renderOne(w, t, taskSchema, opts, func() string {
+		return utils.GetTaskDescription(t, projectColor)
+	})
+}
+
+func Tasks(w io.Writer, tasks []types.Task, opts *Options) error {
+	return renderMany(w, tasks, taskSchema, opts)
+}
+
+func Project(w io.Writer, p types.Project, opts *Options) error {
+	return renderOne(w, p, projectSchema, opts, func() string {
+		return utils.GetProjectDescription(p)
+	})
+}
+
+func Projects(w io.Writer, projects []types.Project, opts *Options) error {
+	return renderMany(w, projects, projectSchema, opts)
+}
+
+func Item(w io.Writer, item types.ChecklistItem, opts *Options) error {
+	return renderOne(w, item, itemSchema, opts, func() string {
+		return itemSchema.line(item)
+	})
+}
+
+func Items(w io.Writer, items []types.ChecklistItem, opts *Options) error {
+	return renderMany(w, items, itemSchema, opts)
+}
+
+// ProjectData renders a project with its tasks, tabular formats and selected fields apply to the tasks
+func ProjectData(w io.Writer, data *types.ProjectData, opts *Options) error {
+	format, err := opts.format()
+	if err != nil {
+		return err
+	}
+	if len(opts.Fields) > 0 || isTabular(format) {
+		return Tasks(w, data.Tasks, opts)
+	}
+	if format == types.OutputSimple {
+		fmt.Fprintln(w, utils.GetProjectDescription(data.Project))
+		for _, t := range data.Tasks {
+			fmt.Fprintln(w, utils.GetTaskDescription(t, data.Project.Color))
+		}
+		return nil
+	}
+	return renderOne(w, *data, schema[types.ProjectData]{}, opts, nil)
+}
+
+func isTabular(format types.OutputFormat) bool {
+	switch format {
+	case types.OutputTable, types.OutputCSV, types.OutputTSV:
+		return true
+	default:
+		return false
+	}
+}
+
+func renderOne[T any](w io.Writer, v T, s schema[T], opts *Options, detail func() string) error {
+	format, err := opts.format()
+	if err != nil {
+		return err
+	}
+	var selected []Field[T]
+	if len(opts.Fields) > 0 {
+		if selected, err = selectFields(s.fields, opts.Fields); err != nil {
+			return err
+		}
+	}
+
+	switch format {
+	case types.OutputSimple:
+		if selected != nil {
+			_, err = fmt.Fprintln(w, strings.Join(newRecord(v, selected).texts(), "\t"))
+			return err
+		}
+		_, err = fmt.Fprintln(w, detail())
+		return err
+	case types.OutputJSON:
+		return writeJSON(w, value(v, selected))
+	case types.OutputYAML:
+		return writeYAML(w, value(v, selected))
+	case types.OutputTemplate:
+		tmpl, err := opts.template()
+		if err != nil {
+			return err
+		}
+		return executeTemplate(w, tmpl, v)
+	default:
+		return renderMany(w, []T{v}, s, opts)
+	}
+}
+
+func renderMany[T any](w io.Writer, vs []T, s schema[T], opts *Options) error {
+	format, err := opts.format()
+	if err != nil {
+		return err
+	}
+	var selected []Field[T]
+	if len(opts.Fields) > 0 {
+		if selected, err = selectFields(s.fields, opts.Fields); err != nil {
+			return err
+		}
+	}
+
+	values := make([]any, len(vs))
+	for i, v := range vs {
+		values[i] = value(v, selected)
+	}
+
+	switch format {
+	case types.OutputJSON:
+		return writeJSON(w, values)
+	case types.OutputYAML:
+		return writeYAML(w, values)
+	case types.OutputNDJSON:
+		return writeNDJSON(w, values)
+	case types.OutputTemplate:
+		tmpl, err := opts.template()
+		if err != nil {
+			return err
+		}
+		for _, v := range vs {
+			if err := executeTemplate(w, tmpl, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	case types.OutputTable, types.OutputCSV, types.OutputTSV:
+		columns := selected
+		if columns == nil {
+			columns = s.fields
+			if format == types.OutputTable {
+				columns, _ = selectFields(s.fields, s.columns)
+			}
+		}
+		header := make([]string, len(columns))
+		for i, f := range columns {
+			header[i] = f.Name
+		}
+		rows := make([][]string, len(vs))
+		for i, v := range vs {
+			rows[i] = newRecord(v, columns).texts()
+		}
+		if format == types.OutputTable {
+			return writeTable(w, header, rows)
+		}
+		return writeDelimited(w, format, header, rows)
+	default:
+		for _, v := range vs {
+			if selected != nil {
+				fmt.Fprintln(w, strings.Join(newRecord(v, selected).texts(), "\t"))
+			} else {
+				fmt.Fprintln(w, s.line(v))
+			}
+		}
+		return nil
+	}
+}
+
+// value returns the structured representation of v, the full object unless fields were selected
+func value[T any](v T, selected []Field[T]) any {
+	if selected == nil {
+		return v
+	}
+	return newRecord(v, selected)
+}
@@ -0,0 +1,94 @@
+package render
+
+import (
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/term"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	columnGap      = "  "
+	minColumnWidth = 6
+)
+
+// writeTable writes aligned columns, truncating the widest ones to fit the terminal
+func writeTable(w io.Writer, header []string, rows [][]string) error {
+	widths := make([]int, len(header))
+	for i, h := range header {
+		widths[i] = runewidth.StringWidth(h)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], runewidth.StringWidth(cell))
+		}
+	}
+	if maxWidth := TerminalWidth(w); maxWidth > 0 {
+		fitWidths(widths, maxWidth-len(columnGap)*(len(widths)-1))
+	}
+
+	upper := make([]string, len(header))
+	for i, h := range header {
+		upper[i] = strings.ToUpper(h)
+	}
+	if err := writeRow(w, upper, widths); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := writeRow(w, row, widths); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeRow(w io.Writer, cells []string, widths []int) error {
+	var sb strings.Builder
+	for i, cell := range cells {
+		cell = runewidth.Truncate(cell, widths[i], "…")
+		if i == len(cells)-1 {
+			sb.WriteString(cell)
+			break
+		}
+		sb.WriteString(runewidth.FillRight(cell, widths[i]))
+		sb.WriteString(columnGap)
+	}
+	sb.WriteByte('\n')
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// fitWidths shrinks the widest columns until the total fits in available
+func fitWidths(widths []int, available int) {
+	total := 0
+	for _, width := range widths {
+		total += width
+	}
+	for total > available {
+		widest := 0
+		for i := range widths {
+			if widths[i] > widths[widest] {
+				widest = i
+			}
+		}
+		if widths[widest] <= minColumnWidth {
+			return
+		}
+		widths[widest]--
+		total--
+	}
+}
+
+// TerminalWidth returns the width of the terminal w writes to, 0 when it isn't a terminal
+func TerminalWidth(w io.Writer) int {
+	f, ok := w.(*os.File)
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return 0
+	}
+	width, _, err := term.GetSize(int(f.Fd()))
+	if err != nil {
+		return 0
+	}
+	return width
+}
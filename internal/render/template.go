@@ -0,0 +1,117 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/dustin/go-humanize"
+	"github.com/gookit/color"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/types/project"
+	"github.com/sho0pi/tickli/internal/types/task"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const textTimeLayout = "2006-01-02 15:04"
+
+// FuncMap returns the helper functions available to output templates
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"humanize": func(v any) string {
+			t, ok := asTime(v)
+			if !ok || t.IsZero() {
+				return ""
+			}
+			return humanize.Time(t)
+		},
+		"date": func(layout string, v any) string {
+			t, ok := asTime(v)
+			if !ok || t.IsZero() {
+				return ""
+			}
+			return t.Format(layout)
+		},
+		"priority": func(p task.Priority) string {
+			return p.Name()
+		},
+		"status": func(s task.Status) string {
+			return s.Name()
+		},
+		"color": func(hex string, a ...any) string {
+			return color.HEX(hex).Sprint(a...)
+		},
+		"priorityColor": func(p task.Priority, a ...any) string {
+			return p.Color().Sprint(a...)
+		},
+		"projectColor": func(c project.Color, a ...any) string {
+			return c.Sprint(a...)
+		},
+		"join": func(sep string, elems []string) string {
+			return strings.Join(elems, sep)
+		},
+		"upper":    strings.ToUpper,
+		"lower":    strings.ToLower,
+		"truncate": truncate,
+		"json": func(v any) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+		"text": Text,
+	}
+}
+
+func asTime(v any) (time.Time, bool) {
+	switch t := v.(type) {
+	case types.TickTickTime:
+		return time.Time(t), true
+	case time.Time:
+		return t, true
+	default:
+		return time.Time{}, false
+	}
+}
+
+func truncate(n int, s string) string {
+	runes := []rune(s)
+	if n <= 0 || len(runes) <= n {
+		return s
+	}
+	if n == 1 {
+		return "…"
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+// Text formats a field value as plain, uncolored text
+func Text(v any) string {
+	switch v := v.(type) {
+	case types.TickTickTime:
+		if v.IsZero() {
+			return ""
+		}
+		return time.Time(v).Format(textTimeLayout)
+	case task.Priority:
+		return v.Name()
+	case task.Status:
+		return v.Name()
+	case project.Kind:
+		return string(v)
+	case project.ViewMode:
+		return string(v)
+	case project.Color:
+		return v.String()
+	case []string:
+		return strings.Join(v, ",")
+	case []types.ChecklistItem:
+		done := 0
+		for _, item := range v {
+			if item.IsCompleted() {
+				done++
+			}
+		}
+		return fmt.Sprintf("%d/%d", done, len(v))
+	default:
+		return fmt.Sprint(v)
+	}
+}
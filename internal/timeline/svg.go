@@ -0,0 +1,88 @@
+package timeline
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+const (
+	svgLabelWidth  = 220
+	svgRowHeight   = 22
+	svgHeaderLines = 2
+	svgPadding     = 10
+	svgFont        = "font-family=\"sans-serif\" font-size=\"12\""
+)
+
+// SVG writes the timeline as a standalone SVG image, one row per task under a header per group
+func SVG(w io.Writer, tl Timeline) error {
+	cellWidth := 24
+	if tl.Scale == ScaleWeek {
+		cellWidth = 40
+	}
+	cells := tl.Cells()
+	rows := 0
+	for _, g := range tl.Groups {
+		rows += 1 + len(g.Bars)
+	}
+	gridX := svgPadding + svgLabelWidth
+	gridY := svgPadding + svgHeaderLines*svgRowHeight
+	width := gridX + cells*cellWidth + svgPadding
+	height := gridY + max(rows, 1)*svgRowHeight + svgPadding
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\" %s>\n", width, height, width, height, svgFont)
+	fmt.Fprintf(&sb, "<rect width=\"%d\" height=\"%d\" fill=\"#FFFFFF\"/>\n", width, height)
+
+	// Header and grid
+	for cell := range cells {
+		x := gridX + cell*cellWidth
+		start := tl.CellStart(cell)
+		if cell == 0 || tl.CellStart(cell-1).Month() != start.Month() {
+			fmt.Fprintf(&sb, "<text x=\"%d\" y=\"%d\" fill=\"#333333\" font-weight=\"bold\">%s</text>\n", x+2, svgPadding+svgRowHeight-6, start.Format("Jan 2006"))
+		}
+		fill := "#777777"
+		if cell == tl.Cell(tl.Today) {
+			fill = "#3694FE"
+		}
+		fmt.Fprintf(&sb, "<text x=\"%d\" y=\"%d\" fill=\"%s\">%d</text>\n", x+2, gridY-6, fill, start.Day())
+		if tl.Scale == ScaleDay && start.Weekday()%6 == 0 {
+			fmt.Fprintf(&sb, "<rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" fill=\"#F4F5F7\"/>\n", x, gridY, cellWidth, height-gridY-svgPadding)
+		}
+		fmt.Fprintf(&sb, "<line x1=\"%d\" y1=\"%d\" x2=\"%d\" y2=\"%d\" stroke=\"#E1E4E8\"/>\n", x, gridY, x, height-svgPadding)
+	}
+
+	y := gridY
+	if len(tl.Groups) == 0 {
+		fmt.Fprintf(&sb, "<text x=\"%d\" y=\"%d\" fill=\"#777777\">No dated tasks</text>\n", svgPadding, y+svgRowHeight-6)
+	}
+	for _, g := range tl.Groups {
+		fmt.Fprintf(&sb, "<rect x=\"%d\" y=\"%d\" width=\"10\" height=\"10\" rx=\"2\" fill=\"%s\"/>\n", svgPadding, y+6, g.Color.String())
+		fmt.Fprintf(&sb, "<text x=\"%d\" y=\"%d\" font-weight=\"bold\">%s</text>\n", svgPadding+16, y+svgRowHeight-6, html.EscapeString(truncate(g.Name, 40)))
+		y += svgRowHeight
+		for _, b := range g.Bars {
+			first, last := max(tl.Cell(b.Start), 0), min(tl.Cell(b.End), cells-1)
+			fill, stroke := "#"+b.Task.Priority.Color().RGB().Hex(), "none"
+			label := html.EscapeString(truncate(b.Task.Title, 32))
+			if b.Overdue {
+				fill, stroke = "#F9D5D3", "#D52B24"
+				label += " <tspan fill=\"#D52B24\">(overdue)</tspan>"
+			}
+			fmt.Fprintf(&sb, "<text x=\"%d\" y=\"%d\">%s</text>\n", svgPadding+16, y+svgRowHeight-6, label)
+			fmt.Fprintf(&sb, "<rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" rx=\"4\" fill=\"%s\" stroke=\"%s\"><title>%s</title></rect>\n",
+				gridX+first*cellWidth+1, y+4, (last-first+1)*cellWidth-2, svgRowHeight-8, fill, stroke,
+				html.EscapeString(fmt.Sprintf("%s: %s – %s", b.Task.Title, b.Start.Format("Jan 2"), b.End.Format("Jan 2"))))
+			y += svgRowHeight
+		}
+	}
+
+	if today := tl.Cell(tl.Today); today >= 0 && today < cells {
+		x := gridX + today*cellWidth + cellWidth/2
+		fmt.Fprintf(&sb, "<line x1=\"%d\" y1=\"%d\" x2=\"%d\" y2=\"%d\" stroke=\"#3694FE\" stroke-width=\"2\" stroke-dasharray=\"4 3\"/>\n", x, gridY, x, height-svgPadding)
+	}
+	sb.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
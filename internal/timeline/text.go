@@ -0,0 +1,156 @@
+package timeline
+
+import (
+	"fmt"
+	"github.com/charmbracelet/lipgloss"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	maxLabelWidth = 32
+	maxCellWidth  = 4
+)
+
+var (
+	dimStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	todayStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#3694FE")).Bold(true)
+	overdueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#D52B24"))
+)
+
+// Text renders the timeline as rows of bars fitting in width columns. Today is marked in the header
+// and down the rows, and overdue bars are drawn hatched and flagged with a "!". Colours are left out
+// when the output is not a terminal.
+func Text(tl Timeline, width int) string {
+	if len(tl.Groups) == 0 {
+		return dimStyle.Render(fmt.Sprintf("No dated tasks between %s and %s", tl.From.Format("Jan 2 2006"), tl.To.Format("Jan 2 2006")))
+	}
+
+	labelWidth := 0
+	for _, g := range tl.Groups {
+		labelWidth = max(labelWidth, lipgloss.Width(g.Name))
+		for _, b := range g.Bars {
+			labelWidth = max(labelWidth, lipgloss.Width(b.Task.Title)+4)
+		}
+	}
+	labelWidth = min(labelWidth, maxLabelWidth)
+	cells := tl.Cells()
+	cellWidth := max(min((width-labelWidth-1)/cells, maxCellWidth), 1)
+	today := tl.Cell(tl.Today)
+
+	var sb strings.Builder
+	pad := strings.Repeat(" ", labelWidth+1)
+	sb.WriteString(pad + tl.monthRow(cellWidth) + "\n")
+	sb.WriteString(pad + tl.dayRow(cellWidth, today) + "\n")
+
+	for _, g := range tl.Groups {
+		swatch := lipgloss.NewStyle().Foreground(lipgloss.Color(g.Color.String())).Render("■")
+		sb.WriteString(swatch + " " + lipgloss.NewStyle().Bold(true).Render(truncate(g.Name, labelWidth-2+cells*cellWidth)) + "\n")
+		for _, b := range g.Bars {
+			label := "  " + truncate(b.Task.Title, labelWidth-4)
+			if b.Overdue {
+				label += " " + overdueStyle.Render("!")
+			}
+			label += strings.Repeat(" ", max(labelWidth-lipgloss.Width(label), 0))
+			sb.WriteString(label + " " + tl.barRow(b, cellWidth, today) + "\n")
+		}
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// monthRow names the month of the first cell and of each cell starting a new month
+func (tl Timeline) monthRow(cellWidth int) string {
+	row := []rune(strings.Repeat(" ", tl.Cells()*cellWidth))
+	next := 0
+	for cell := range tl.Cells() {
+		start := tl.CellStart(cell)
+		if cell > 0 && tl.CellStart(cell-1).Month() == start.Month() {
+			continue
+		}
+		name := start.Format("Jan")
+		if cell == 0 || start.Month() == 1 {
+			name = start.Format("Jan 2006")
+		}
+		pos := cell * cellWidth
+		if pos < next || pos+len(name) > len(row) {
+			continue
+		}
+		copy(row[pos:], []rune(name))
+		next = pos + len(name) + 1
+	}
+	return dimStyle.Render(string(row))
+}
+
+// dayRow numbers the cells by the day of the month they start on. Numbers that would run together
+// on a narrow day scale are only written on Mondays, and today's cell is highlighted.
+func (tl Timeline) dayRow(cellWidth, today int) string {
+	row := []rune(strings.Repeat(" ", tl.Cells()*cellWidth))
+	next := 0
+	for cell := range tl.Cells() {
+		start := tl.CellStart(cell)
+		if tl.Scale == ScaleDay && cellWidth < 3 && start.Weekday() != time.Monday && cell != today {
+			continue
+		}
+		number := strconv.Itoa(start.Day())
+		if cell == today && cellWidth == 1 {
+			number = "▼"
+		}
+		pos := cell * cellWidth
+		if pos < next || pos+len([]rune(number)) > len(row) {
+			continue
+		}
+		copy(row[pos:], []rune(number))
+		next = pos + len([]rune(number)) + 1
+	}
+
+	if today < 0 || today >= tl.Cells() {
+		return dimStyle.Render(string(row))
+	}
+	end := min((today+1)*cellWidth, len(row))
+	for end < len(row) && row[end] != ' ' {
+		end++
+	}
+	return dimStyle.Render(string(row[:today*cellWidth])) + todayStyle.Render(string(row[today*cellWidth:end])) +
+		dimStyle.Render(string(row[end:]))
+}
+
+// barRow draws the bar across its cells, with arrows where it runs past the edges of the timeline
+func (tl Timeline) barRow(b Bar, cellWidth, today int) string {
+	first, last := tl.Cell(b.Start), tl.Cell(b.End)
+	fill, style := "█", lipgloss.NewStyle().Foreground(lipgloss.Color("#"+b.Task.Priority.Color().RGB().Hex()))
+	if b.Overdue {
+		fill, style = "▒", overdueStyle
+	}
+
+	var sb strings.Builder
+	for cell := range tl.Cells() {
+		switch {
+		case cell == 0 && first < 0 && last >= 0:
+			sb.WriteString(style.Render("◀" + strings.Repeat(fill, cellWidth-1)))
+		case cell == tl.Cells()-1 && last > cell && first <= cell:
+			sb.WriteString(style.Render(strings.Repeat(fill, cellWidth-1) + "▶"))
+		case cell >= first && cell <= last:
+			sb.WriteString(style.Render(strings.Repeat(fill, cellWidth)))
+		case cell == today:
+			sb.WriteString(todayStyle.Render("┊" + strings.Repeat(" ", cellWidth-1)))
+		default:
+			sb.WriteString(dimStyle.Render("·" + strings.Repeat(" ", cellWidth-1)))
+		}
+	}
+	return sb.String()
+}
+
+func truncate(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+1 > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
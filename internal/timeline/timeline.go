@@ -0,0 +1,261 @@
+package timeline
+
+import (
+	"fmt"
+	"github.com/sho0pi/tickli/internal/export"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/types/project"
+	"github.com/sho0pi/tickli/internal/types/task"
+	"github.com/spf13/cobra"
+	"time"
+)
+
+// Scale is the length of a timeline cell
+type Scale string
+
+const (
+	ScaleDay  Scale = "day"
+	ScaleWeek Scale = "week"
+)
+
+var ScaleCompletion = []cobra.Completion{
+	cobra.CompletionWithDesc(string(ScaleDay), "One cell per day"),
+	cobra.CompletionWithDesc(string(ScaleWeek), "One cell per week, starting on Monday"),
+}
+
+var ScaleCompletionFunc = cobra.FixedCompletions(ScaleCompletion, cobra.ShellCompDirectiveNoFileComp)
+
+func (s *Scale) Set(value string) error {
+	switch Scale(value) {
+	case ScaleDay, ScaleWeek:
+		*s = Scale(value)
+	default:
+		return fmt.Errorf("invalid timeline scale: %s", value)
+	}
+	return nil
+}
+
+func (s Scale) String() string {
+	return string(s)
+}
+
+func (s *Scale) Type() string {
+	return "TimelineScale"
+}
+
+// GroupBy decides how bars are grouped
+type GroupBy string
+
+const (
+	GroupByProject GroupBy = "project"
+	GroupByColumn  GroupBy = "column"
+)
+
+var GroupByCompletion = []cobra.Completion{
+	cobra.CompletionWithDesc(string(GroupByProject), "One group per project"),
+	cobra.CompletionWithDesc(string(GroupByColumn), "One group per kanban column of each project"),
+}
+
+var GroupByCompletionFunc = cobra.FixedCompletions(GroupByCompletion, cobra.ShellCompDirectiveNoFileComp)
+
+func (g *GroupBy) Set(value string) error {
+	switch GroupBy(value) {
+	case GroupByProject, GroupByColumn:
+		*g = GroupBy(value)
+	default:
+		return fmt.Errorf("invalid timeline grouping: %s", value)
+	}
+	return nil
+}
+
+func (g GroupBy) String() string {
+	return string(g)
+}
+
+func (g *GroupBy) Type() string {
+	return "TimelineGroupBy"
+}
+
+// Bar is the span of a task, from its first to its last day
+type Bar struct {
+	Task  types.Task
+	Start time.Time
+	End   time.Time
+	// Overdue is set for open tasks due before now
+	Overdue bool
+}
+
+type Group struct {
+	Name  string
+	Color project.Color
+	Bars  []Bar
+}
+
+// Timeline is the tasks spanning the days from From to To, both included
+type Timeline struct {
+	From   time.Time
+	To     time.Time
+	Today  time.Time
+	Scale  Scale
+	Groups []Group
+}
+
+type Options struct {
+	From, To time.Time
+	Scale    Scale
+	GroupBy  GroupBy
+	Now      time.Time
+}
+
+// Build lays out the dated tasks of the projects overlapping the range. A task spans from its start
+// to its due date, a task with only one of them takes a single day.
+func Build(projects []types.ProjectData, opts Options) Timeline {
+	tl := Timeline{
+		From:  Day(opts.From),
+		To:    Day(opts.To),
+		Today: Day(opts.Now),
+		Scale: opts.Scale,
+	}
+	if tl.Scale == ScaleWeek {
+		tl.From = weekStart(tl.From)
+		tl.To = weekStart(tl.To).AddDate(0, 0, 6)
+	}
+
+	for _, data := range projects {
+		if opts.GroupBy == GroupByColumn {
+			for _, g := range export.GroupByColumn(&data) {
+				name := data.Project.Name
+				if g.Name != "" {
+					name += " › " + g.Name
+				}
+				tl.add(Group{Name: name, Color: data.Project.Color}, g.Tasks, opts.Now)
+			}
+			continue
+		}
+		tl.add(Group{Name: data.Project.Name, Color: data.Project.Color}, data.Tasks, opts.Now)
+	}
+	return tl
+}
+
+func (tl *Timeline) add(group Group, tasks []types.Task, now time.Time) {
+	for _, t := range tasks {
+		bar, ok := newBar(t, now)
+		if !ok || bar.End.Before(tl.From) || bar.Start.After(tl.To) {
+			continue
+		}
+		group.Bars = append(group.Bars, bar)
+	}
+	if len(group.Bars) > 0 {
+		tl.Groups = append(tl.Groups, group)
+	}
+}
+
+func newBar(t types.Task, now time.Time) (Bar, bool) {
+	start, due := t.StartDate, t.DueDate
+	switch {
+	case start.IsZero() && due.IsZero():
+		return Bar{}, false
+	case start.IsZero():
+		start = due
+	case due.IsZero():
+		due = start
+	}
+
+	bar := Bar{
+		Task:  t,
+		Start: Day(export.LocalTime(start, t.TimeZone)),
+		End:   Day(export.LocalTime(due, t.TimeZone)),
+	}
+	dueAt := export.LocalTime(due, t.TimeZone)
+	// All-day spans end at the midnight after their last day
+	if t.IsAllDay && bar.End.After(bar.Start) {
+		bar.End = bar.End.AddDate(0, 0, -1)
+	}
+	if t.IsAllDay {
+		dueAt = bar.End.AddDate(0, 0, 1)
+	}
+	if bar.End.Before(bar.Start) {
+		bar.End = bar.Start
+	}
+	bar.Overdue = t.Status != task.StatusComplete && !t.DueDate.IsZero() && dueAt.Before(now)
+	return bar, true
+}
+
+// Cells returns the number of cells of the timeline
+func (tl Timeline) Cells() int {
+	days := DaysBetween(tl.From, tl.To) + 1
+	if tl.Scale == ScaleWeek {
+		return (days + 6) / 7
+	}
+	return days
+}
+
+// CellStart returns the first day of the cell
+func (tl Timeline) CellStart(cell int) time.Time {
+	if tl.Scale == ScaleWeek {
+		return tl.From.AddDate(0, 0, 7*cell)
+	}
+	return tl.From.AddDate(0, 0, cell)
+}
+
+// Cell returns the cell of a day, which may be out of the timeline
+func (tl Timeline) Cell(day time.Time) int {
+	days := DaysBetween(tl.From, day)
+	if tl.Scale == ScaleWeek {
+		if days < 0 {
+			return (days - 6) / 7
+		}
+		return days / 7
+	}
+	return days
+}
+
+// Day returns the local midnight starting the day of t, keeping its calendar date
+func Day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// DaysBetween counts the calendar days from a to b
+func DaysBetween(a, b time.Time) int {
+	ua := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	ub := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(ub.Sub(ua).Hours() / 24)
+}
+
+func weekStart(day time.Time) time.Time {
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// Format is the output of the timeline
+type Format string
+
+const (
+	FormatText Format = "text"
+	FormatSVG  Format = "svg"
+)
+
+var FormatCompletion = []cobra.Completion{
+	cobra.CompletionWithDesc(string(FormatText), "Bars drawn with text, coloured on a terminal"),
+	cobra.CompletionWithDesc(string(FormatSVG), "A standalone SVG image"),
+}
+
+var FormatCompletionFunc = cobra.FixedCompletions(FormatCompletion, cobra.ShellCompDirectiveNoFileComp)
+
+func (f *Format) Set(value string) error {
+	switch Format(value) {
+	case FormatText, FormatSVG:
+		*f = Format(value)
+	default:
+		return fmt.Errorf("invalid timeline format: %s", value)
+	}
+	return nil
+}
+
+func (f Format) String() string {
+	return string(f)
+}
+
+func (f *Format) Type() string {
+	return "TimelineFormat"
+}
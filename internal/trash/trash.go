@@ -0,0 +1,194 @@
+package trash
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/sho0pi/tickli/internal/types"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultRetention is how long deleted items are kept when the config doesn't set it
+const DefaultRetention = 30 * 24 * time.Hour
+
+// Kind is the kind of item deleted
+type Kind string
+
+const (
+	KindTask    Kind = "task"
+	KindProject Kind = "project"
+)
+
+// Item is a deleted task or project, projects holding their tasks and columns
+type Item struct {
+	// ID is the ID the task or project had
+	ID        string             `json:"id"`
+	Kind      Kind               `json:"kind"`
+	DeletedAt time.Time          `json:"deletedAt"`
+	Task      *types.Task        `json:"task,omitempty"`
+	Project   *types.ProjectData `json:"project,omitempty"`
+}
+
+// TaskItem returns the trash item of a deleted task
+func TaskItem(t types.Task) Item {
+	return Item{ID: t.ID, Kind: KindTask, Task: &t}
+}
+
+// ProjectItem returns the trash item of a deleted project
+func ProjectItem(data types.ProjectData) Item {
+	return Item{ID: data.Project.ID, Kind: KindProject, Project: &data}
+}
+
+// Title is the title of the task or name of the project
+func (i Item) Title() string {
+	if i.Kind == KindProject {
+		return i.Project.Project.Name
+	}
+	return i.Task.Title
+}
+
+// Trash stores deleted items on disk, one file per item, dropping them after the retention
+type Trash struct {
+	dir string
+	// retention is how long items are kept, 0 keeps them until purged
+	retention time.Duration
+}
+
+func Open(dir string, retention time.Duration) *Trash {
+	return &Trash{dir: dir, retention: retention}
+}
+
+// Retention returns how long items are kept, 0 when they are kept until purged
+func (t *Trash) Retention() time.Duration {
+	return t.retention
+}
+
+// Put stores the item, stamping it with the time of deletion, and drops the expired items. Failing
+// to drop them doesn't fail the put, they are dropped by a later one.
+func (t *Trash) Put(item Item) error {
+	item.DeletedAt = time.Now()
+	data, err := json.Marshal(item)
+	if err != nil {
+		return errors.Wrap(err, "encoding trash item")
+	}
+	if err := os.MkdirAll(t.dir, 0700); err != nil {
+		return errors.Wrap(err, "creating trash directory")
+	}
+	tmp := t.path(item.ID) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return errors.Wrap(err, "writing trash item")
+	}
+	if err := os.Rename(tmp, t.path(item.ID)); err != nil {
+		return errors.Wrap(err, "writing trash item")
+	}
+
+	if _, err := t.Prune(); err != nil {
+		log.Warn().Err(err).Msg("Failed to drop expired trash items")
+	}
+	return nil
+}
+
+// Get returns the item of the task or project ID
+func (t *Trash) Get(id string) (Item, error) {
+	item, err := t.get(id)
+	if os.IsNotExist(err) {
+		return Item{}, fmt.Errorf("%s is not in the trash", id)
+	}
+	return item, err
+}
+
+// get reads an item, returning the os.ReadFile error unwrapped for missing items
+func (t *Trash) get(id string) (Item, error) {
+	data, err := os.ReadFile(t.path(id))
+	if os.IsNotExist(err) {
+		return Item{}, err
+	}
+	if err != nil {
+		return Item{}, errors.Wrap(err, "reading trash item")
+	}
+	var item Item
+	if err := json.Unmarshal(data, &item); err != nil {
+		return Item{}, errors.Wrapf(err, "decoding trash item %s", id)
+	}
+	return item, nil
+}
+
+// List returns the items in the trash, most recently deleted first. Items removed meanwhile, by a
+// concurrent purge, are left out.
+func (t *Trash) List() ([]Item, error) {
+	files, err := os.ReadDir(t.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "reading trash")
+	}
+
+	var items []Item
+	for _, f := range files {
+		name, ok := strings.CutSuffix(f.Name(), ".json")
+		if !ok || f.IsDir() {
+			continue
+		}
+		id, err := url.PathUnescape(name)
+		if err != nil {
+			continue
+		}
+		item, err := t.get(id)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	slices.SortFunc(items, func(a, b Item) int { return b.DeletedAt.Compare(a.DeletedAt) })
+	return items, nil
+}
+
+// Remove drops the item of the task or project ID, missing items are ignored
+func (t *Trash) Remove(id string) error {
+	if err := os.Remove(t.path(id)); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "removing trash item")
+	}
+	return nil
+}
+
+// Purge drops the items deleted before the given time, returning how many were dropped.
+// A zero time drops every item.
+func (t *Trash) Purge(before time.Time) (int, error) {
+	items, err := t.List()
+	if err != nil {
+		return 0, err
+	}
+	purged := 0
+	for _, item := range items {
+		if !before.IsZero() && !item.DeletedAt.Before(before) {
+			continue
+		}
+		if err := t.Remove(item.ID); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// Prune drops the items older than the retention
+func (t *Trash) Prune() (int, error) {
+	if t.retention <= 0 {
+		return 0, nil
+	}
+	return t.Purge(time.Now().Add(-t.retention))
+}
+
+func (t *Trash) path(id string) string {
+	return filepath.Join(t.dir, url.PathEscape(id)+".json")
+}
@@ -0,0 +1,165 @@
+package tui
+
+import (
+	"fmt"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sho0pi/tickli/internal/export"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/types/task"
+	"strings"
+)
+
+const (
+	// minColumnWidth is the narrowest a board column gets before columns wrap to another row
+	minColumnWidth = 26
+	columnGap      = 1
+	noColumnName   = "No column"
+)
+
+var chipStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")).Background(lipgloss.Color("#5A6270"))
+
+// boardCursor is the selected card, nil when nothing is selected
+type boardCursor struct {
+	column, card int
+}
+
+// RenderBoard lays out the tasks of the project by kanban column, side by side. Columns are as wide
+// as the width allows, wrapping to another row of columns when they would get too narrow.
+func RenderBoard(data *types.ProjectData, width int) string {
+	groups := export.GroupByColumn(data)
+	perRow := columnsPerRow(width, len(groups))
+	var rows []string
+	for start := 0; start < len(groups); start += perRow {
+		end := min(start+perRow, len(groups))
+		rows = append(rows, renderColumns(data.Project, groups[start:end], start, columnWidth(width, perRow), 0, nil))
+	}
+	return strings.Join(rows, "\n\n")
+}
+
+func columnsPerRow(width, columns int) int {
+	return max(min((width+columnGap)/(minColumnWidth+columnGap), columns), 1)
+}
+
+func columnWidth(width, perRow int) int {
+	return max((width-columnGap*(perRow-1))/perRow, minColumnWidth)
+}
+
+// renderColumns renders the groups side by side, first being the index of groups[0] on the board.
+// A positive height limits the column length, scrolling the selected card into view.
+func renderColumns(p types.Project, groups []export.Group, first, width, height int, cursor *boardCursor) string {
+	header := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(p.Color.String())).
+		Width(width).BorderStyle(lipgloss.NormalBorder()).BorderBottom(true).
+		BorderForeground(lipgloss.Color(p.Color.String()))
+
+	columns := make([]string, len(groups))
+	for i, g := range groups {
+		name := g.Name
+		if name == "" {
+			name = noColumnName
+		}
+		title := header.Render(truncate(fmt.Sprintf("%s %d", name, len(g.Tasks)), width))
+
+		var cards []string
+		selected := -1
+		for j, t := range g.Tasks {
+			focused := cursor != nil && cursor.column == first+i && cursor.card == j
+			if focused {
+				selected = j
+			}
+			cards = append(cards, renderCard(t, width, focused))
+		}
+		if len(cards) == 0 {
+			cards = append(cards, dimStyle.Width(width).Render("  empty"))
+		}
+		if height > 0 {
+			cards = scrollCards(cards, selected, height-lipgloss.Height(title))
+		}
+		columns[i] = lipgloss.JoinVertical(lipgloss.Left, append([]string{title}, cards...)...)
+	}
+
+	gap := strings.Repeat(" ", columnGap)
+	var parts []string
+	for i, c := range columns {
+		if i > 0 {
+			parts = append(parts, gap)
+		}
+		parts = append(parts, c)
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, parts...)
+}
+
+// scrollCards drops the cards before the selected one until it fits in height lines
+func scrollCards(cards []string, selected, height int) []string {
+	total := 0
+	for _, c := range cards {
+		total += lipgloss.Height(c)
+	}
+	start := 0
+	for start < selected && total > height {
+		total -= lipgloss.Height(cards[start])
+		start++
+	}
+	return cards[start:]
+}
+
+// renderCard draws a task with a border in its priority colour, its due date and tag chips
+func renderCard(t types.Task, width int, focused bool) string {
+	border := lipgloss.RoundedBorder()
+	if focused {
+		border = lipgloss.ThickBorder()
+	}
+	style := lipgloss.NewStyle().Border(border).Padding(0, 1).Width(width - 2).
+		BorderForeground(lipgloss.Color("#" + t.Priority.Color().RGB().Hex()))
+	inner := width - 4
+
+	lines := []string{lipgloss.NewStyle().Bold(focused).Width(inner).Render(t.Title)}
+	var meta []string
+	if t.Priority != task.PriorityNone {
+		meta = append(meta, priorityFlag(t.Priority))
+	}
+	if due := shortDate(t); due != "" {
+		if isOverdue(t) {
+			meta = append(meta, overdueStyle.Render("📅 "+due))
+		} else {
+			meta = append(meta, dimStyle.Render("📅 "+due))
+		}
+	}
+	if len(t.Items) > 0 {
+		done := 0
+		for _, item := range t.Items {
+			if item.IsCompleted() {
+				done++
+			}
+		}
+		meta = append(meta, dimStyle.Render(fmt.Sprintf("☑ %d/%d", done, len(t.Items))))
+	}
+	if len(meta) > 0 {
+		lines = append(lines, strings.Join(meta, " "))
+	}
+	lines = append(lines, tagChips(t.Tags, inner)...)
+	return style.Render(strings.Join(lines, "\n"))
+}
+
+// tagChips renders the tags as chips, wrapped to the width
+func tagChips(tags []string, width int) []string {
+	var lines []string
+	line, lineWidth := "", 0
+	for _, tag := range tags {
+		chip := chipStyle.Render(" " + truncate(tag, width-2) + " ")
+		w := lipgloss.Width(chip)
+		if lineWidth > 0 && lineWidth+1+w > width {
+			lines = append(lines, line)
+			line, lineWidth = "", 0
+		}
+		if lineWidth > 0 {
+			line += " "
+			lineWidth++
+		}
+		line += chip
+		lineWidth += w
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
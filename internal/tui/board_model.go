@@ -0,0 +1,148 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/export"
+	"github.com/sho0pi/tickli/internal/types"
+)
+
+// BoardModel is the interactive kanban board of a project, moving cards between columns
+type BoardModel struct {
+	client    *api.Client
+	projectID string
+	data      *types.ProjectData
+	groups    []export.Group
+	cursor    boardCursor
+
+	status string
+	err    error
+	width  int
+	height int
+}
+
+func NewBoard(client *api.Client, projectID string) BoardModel {
+	return BoardModel{client: client, projectID: projectID}
+}
+
+// RunBoard starts the interactive board in the alternate screen until the user quits
+func RunBoard(client *api.Client, projectID string) error {
+	_, err := tea.NewProgram(NewBoard(client, projectID), tea.WithAltScreen()).Run()
+	return err
+}
+
+func (m BoardModel) Init() tea.Cmd {
+	return loadData(m.client, m.projectID)
+}
+
+func (m BoardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+	case dataMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		selected, hadCard := m.card()
+		m.data, m.groups = msg.data, export.GroupByColumn(msg.data)
+		if hadCard {
+			// Follow the card to its new column
+			for i, g := range m.groups {
+				for j, t := range g.Tasks {
+					if t.ID == selected.ID {
+						m.cursor = boardCursor{column: i, card: j}
+					}
+				}
+			}
+		}
+		m.clamp()
+	case doneMsg:
+		m.status, m.err = msg.status, msg.err
+		return m, loadData(m.client, m.projectID)
+	case tea.KeyMsg:
+		m.err = nil
+		switch msg.String() {
+		case "q", "ctrl+c", "esc":
+			return m, tea.Quit
+		case "h", "left":
+			m.cursor.column--
+		case "l", "right":
+			m.cursor.column++
+		case "k", "up":
+			m.cursor.card--
+		case "j", "down":
+			m.cursor.card++
+		case "H", "shift+left", "<":
+			return m, m.move(-1)
+		case "L", "shift+right", ">":
+			return m, m.move(1)
+		case "r":
+			m.status = "Refreshing…"
+			return m, loadData(m.client.WithRefresh(), m.projectID)
+		}
+		m.clamp()
+	}
+	return m, nil
+}
+
+// move sends the selected card to the next column in the direction, skipping the group of
+// tasks outside any column: a task cannot be taken out of its column through the API
+func (m BoardModel) move(direction int) tea.Cmd {
+	t, ok := m.card()
+	if !ok {
+		return nil
+	}
+	target := m.cursor.column + direction
+	if target >= 0 && target < len(m.groups) && m.groups[target].ColumnID == "" {
+		target += direction
+	}
+	if target < 0 || target >= len(m.groups) {
+		return nil
+	}
+	t.ColumnID = m.groups[target].ColumnID
+	return updateTask(m.client, t, "Moved "+quote(t.Title)+" to "+m.groups[target].Name)
+}
+
+func (m BoardModel) card() (types.Task, bool) {
+	if m.cursor.column < 0 || m.cursor.column >= len(m.groups) {
+		return types.Task{}, false
+	}
+	tasks := m.groups[m.cursor.column].Tasks
+	if m.cursor.card < 0 || m.cursor.card >= len(tasks) {
+		return types.Task{}, false
+	}
+	return tasks[m.cursor.card], true
+}
+
+func (m *BoardModel) clamp() {
+	m.cursor.column = clamp(m.cursor.column, len(m.groups))
+	if len(m.groups) > 0 {
+		m.cursor.card = clamp(m.cursor.card, len(m.groups[m.cursor.column].Tasks))
+	}
+}
+
+func (m BoardModel) View() string {
+	switch {
+	case m.width == 0 || m.data == nil && m.err == nil:
+		return "Loading…"
+	case m.data == nil:
+		return errorStyle.Render("Error: " + m.err.Error())
+	}
+
+	// Show the columns that fit, scrolled to keep the selected one visible
+	perRow := columnsPerRow(m.width, len(m.groups))
+	first := max(min(m.cursor.column-perRow/2, len(m.groups)-perRow), 0)
+	board := renderColumns(m.data.Project, m.groups[first:first+perRow], first,
+		columnWidth(m.width, perRow), m.height-2, &m.cursor)
+
+	footer := dimStyle.Render(truncate("←→ column · ↑↓ card · H/L move card · r refresh · q quit", m.width-2))
+	switch {
+	case m.err != nil:
+		footer = errorStyle.Render(truncate("Error: "+m.err.Error(), m.width-2))
+	case m.status != "":
+		footer = truncate(m.status, m.width-2)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lipgloss.NewStyle().Height(m.height-2).MaxHeight(m.height-2).Render(board), " "+footer)
+}
@@ -0,0 +1,143 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/types/task"
+	"slices"
+	"time"
+)
+
+type projectsMsg struct {
+	projects []types.Project
+	err      error
+}
+
+type dataMsg struct {
+	projectID string
+	data      *types.ProjectData
+	err       error
+}
+
+// doneMsg reports a finished change, the current project is reloaded after it
+type doneMsg struct {
+	status string
+	err    error
+}
+
+type refreshMsg time.Time
+
+// loadProjects lists the open projects, the inbox first
+func loadProjects(client *api.Client) tea.Cmd {
+	return func() tea.Msg {
+		projects, err := client.ListProjects()
+		if err != nil {
+			return projectsMsg{err: err}
+		}
+		projects = slices.DeleteFunc(projects, func(p types.Project) bool {
+			return p.Closed
+		})
+		// The API lists the inbox last
+		if i := slices.IndexFunc(projects, func(p types.Project) bool { return p.ID == types.InboxProject.ID }); i > 0 {
+			inbox := projects[i]
+			projects = append([]types.Project{inbox}, slices.Delete(projects, i, i+1)...)
+		}
+		return projectsMsg{projects: projects}
+	}
+}
+
+func loadData(client *api.Client, projectID string) tea.Cmd {
+	return func() tea.Msg {
+		data, err := client.GetProjectWithTasks(projectID)
+		if data != nil {
+			data.Tasks = sortTasks(data.Tasks)
+		}
+		return dataMsg{projectID: projectID, data: data, err: err}
+	}
+}
+
+// sortTasks orders tasks like the TickTick list view: by priority, then due date, then sort order
+func sortTasks(tasks []types.Task) []types.Task {
+	slices.SortStableFunc(tasks, func(a, b types.Task) int {
+		if a.Priority != b.Priority {
+			return int(b.Priority) - int(a.Priority)
+		}
+		switch {
+		case a.DueDate.IsZero() && !b.DueDate.IsZero():
+			return 1
+		case !a.DueDate.IsZero() && b.DueDate.IsZero():
+			return -1
+		case !a.DueDate.Equal(b.DueDate):
+			return time.Time(a.DueDate).Compare(time.Time(b.DueDate))
+		}
+		switch {
+		case a.SortOrder < b.SortOrder:
+			return -1
+		case a.SortOrder > b.SortOrder:
+			return 1
+		}
+		return 0
+	})
+	return tasks
+}
+
+func change(status string, fn func() error) tea.Cmd {
+	return func() tea.Msg {
+		if err := fn(); err != nil {
+			return doneMsg{err: err}
+		}
+		return doneMsg{status: status}
+	}
+}
+
+func completeTask(client *api.Client, t types.Task) tea.Cmd {
+	return change("Completed "+quote(t.Title), func() error {
+		return client.CompleteTask(t.ProjectID, t.ID)
+	})
+}
+
+func updateTask(client *api.Client, t types.Task, status string) tea.Cmd {
+	return change(status, func() error {
+		_, err := client.UpdateTask(&t)
+		return err
+	})
+}
+
+func createTask(client *api.Client, projectID, title string) tea.Cmd {
+	return change("Created "+quote(title), func() error {
+		_, err := client.CreateTask(&types.Task{ProjectID: projectID, Title: title})
+		return err
+	})
+}
+
+func deleteTask(client *api.Client, t types.Task) tea.Cmd {
+	return change("Deleted "+quote(t.Title), func() error {
+		return client.DeleteTask(t.ProjectID, t.ID)
+	})
+}
+
+func moveTask(client *api.Client, t types.Task, to types.Project) tea.Cmd {
+	return change("Moved "+quote(t.Title)+" to "+to.Name, func() error {
+		_, err := client.MoveTask(t, to.ID)
+		return err
+	})
+}
+
+// nextPriority cycles none, low, medium and high
+func nextPriority(p task.Priority) task.Priority {
+	switch p {
+	case task.PriorityNone:
+		return task.PriorityLow
+	case task.PriorityLow:
+		return task.PriorityMedium
+	case task.PriorityMedium:
+		return task.PriorityHigh
+	default:
+		return task.PriorityNone
+	}
+}
+
+func quote(s string) string {
+	return "\"" + s + "\""
+}
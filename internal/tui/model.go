@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/types"
+	"time"
+)
+
+type focus int
+
+const (
+	focusProjects focus = iota
+	focusTasks
+	focusItems
+)
+
+type mode int
+
+const (
+	modeNormal mode = iota
+	// modeInput edits the title of a new or selected task
+	modeInput
+	// modeConfirm waits for y or n
+	modeConfirm
+	// modeMove picks the project to move the selected task to
+	modeMove
+)
+
+// Model is the full-screen task browser: a project sidebar, the tasks of the selected
+// project, and the details and checklist items of the selected task
+type Model struct {
+	client       *api.Client
+	refreshEvery time.Duration
+
+	projects   []types.Project
+	projectIdx int
+	data       *types.ProjectData
+	taskIdx    int
+	itemIdx    int
+
+	focus   focus
+	mode    mode
+	input   textinput.Model
+	editing string // ID of the task whose title is edited, empty when creating
+	confirm string
+	onYes   tea.Cmd
+	moveIdx int
+
+	status  string
+	err     error
+	loading bool
+	width   int
+	height  int
+}
+
+// New returns the model of the task browser, refreshing projects and tasks every refreshEvery
+func New(client *api.Client, refreshEvery time.Duration) Model {
+	input := textinput.New()
+	input.CharLimit = 500
+	return Model{
+		client:       client,
+		refreshEvery: refreshEvery,
+		input:        input,
+		focus:        focusProjects,
+		loading:      true,
+	}
+}
+
+// Run starts the task browser in the alternate screen until the user quits
+func Run(client *api.Client, refreshEvery time.Duration) error {
+	_, err := tea.NewProgram(New(client, refreshEvery), tea.WithAltScreen()).Run()
+	return err
+}
+
+func (m Model) Init() tea.Cmd {
+	return tea.Batch(loadProjects(m.client), m.scheduleRefresh())
+}
+
+func (m Model) project() (types.Project, bool) {
+	if m.projectIdx < 0 || m.projectIdx >= len(m.projects) {
+		return types.NullProject, false
+	}
+	return m.projects[m.projectIdx], true
+}
+
+func (m Model) tasks() []types.Task {
+	if m.data == nil {
+		return nil
+	}
+	return m.data.Tasks
+}
+
+func (m Model) task() (types.Task, bool) {
+	tasks := m.tasks()
+	if m.taskIdx < 0 || m.taskIdx >= len(tasks) {
+		return types.Task{}, false
+	}
+	return tasks[m.taskIdx], true
+}
+
+// reload fetches the projects and the current project again, past the cache
+func (m Model) reload() tea.Cmd {
+	refresh := m.client.WithRefresh()
+	cmds := []tea.Cmd{loadProjects(refresh)}
+	if p, ok := m.project(); ok {
+		cmds = append(cmds, loadData(refresh, p.ID))
+	}
+	return tea.Batch(cmds...)
+}
+
+// scheduleRefresh reloads after the refresh interval, each refresh schedules the next one
+func (m Model) scheduleRefresh() tea.Cmd {
+	if m.refreshEvery <= 0 {
+		return nil
+	}
+	return tea.Tick(m.refreshEvery, func(t time.Time) tea.Msg {
+		return refreshMsg(t)
+	})
+}
@@ -0,0 +1,262 @@
+package tui
+
+import (
+	"fmt"
+	tea "github.com/charmbracelet/bubbletea"
+	"slices"
+	"strings"
+)
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+
+	case projectsMsg:
+		if msg.err != nil {
+			m.err, m.loading = msg.err, false
+			return m, nil
+		}
+		selected, _ := m.project()
+		m.projects = msg.projects
+		m.projectIdx = 0
+		for i, p := range m.projects {
+			if p.ID == selected.ID {
+				m.projectIdx = i
+			}
+		}
+		if p, ok := m.project(); ok && (m.data == nil || selected.ID != p.ID) {
+			return m, loadData(m.client, p.ID)
+		}
+		m.loading = false
+		return m, nil
+
+	case dataMsg:
+		p, ok := m.project()
+		if !ok || p.ID != msg.projectID {
+			return m, nil
+		}
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		// Keep the selection on the same task when it moved in the list
+		selected, hadTask := m.task()
+		m.data = msg.data
+		if hadTask {
+			m.taskIdx = min(m.taskIdx, max(len(m.data.Tasks)-1, 0))
+			for i, t := range m.data.Tasks {
+				if t.ID == selected.ID {
+					m.taskIdx = i
+				}
+			}
+		}
+		m.clampItem()
+		return m, nil
+
+	case doneMsg:
+		m.status, m.err = msg.status, msg.err
+		if p, ok := m.project(); ok {
+			return m, loadData(m.client, p.ID)
+		}
+		return m, nil
+
+	case refreshMsg:
+		return m, tea.Batch(m.reload(), m.scheduleRefresh())
+
+	case tea.KeyMsg:
+		switch m.mode {
+		case modeInput:
+			return m.updateInput(msg)
+		case modeConfirm:
+			return m.updateConfirm(msg)
+		case modeMove:
+			return m.updateMove(msg)
+		}
+		return m.updateKey(msg)
+	}
+	return m, nil
+}
+
+func (m Model) updateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.err = nil
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	case "tab", "l", "right":
+		m.focus = min(m.focus+1, focusItems)
+		if m.focus == focusItems {
+			if t, ok := m.task(); !ok || len(t.Items) == 0 {
+				m.focus = focusTasks
+			}
+		}
+		return m, nil
+	case "shift+tab", "h", "left", "esc":
+		m.focus = max(m.focus-1, focusProjects)
+		return m, nil
+	case "j", "down":
+		return m.moveCursor(1)
+	case "k", "up":
+		return m.moveCursor(-1)
+	case "g", "home":
+		return m.moveCursor(-1 << 30)
+	case "G", "end":
+		return m.moveCursor(1 << 30)
+	case "enter":
+		if m.focus == focusProjects {
+			m.focus = focusTasks
+		}
+		return m, nil
+	case "r":
+		m.status = "Refreshing…"
+		return m, m.reload()
+	case "n", "a":
+		if _, ok := m.project(); !ok {
+			return m, nil
+		}
+		m.mode, m.editing = modeInput, ""
+		m.input.Placeholder = "New task title"
+		m.input.SetValue("")
+		return m, m.input.Focus()
+	}
+
+	t, ok := m.task()
+	if !ok || m.focus == focusProjects {
+		return m, nil
+	}
+	switch msg.String() {
+	case " ", "x":
+		if m.focus == focusItems && m.itemIdx < len(t.Items) {
+			t.Items = slices.Clone(t.Items)
+			item := &t.Items[m.itemIdx]
+			if item.IsCompleted() {
+				item.Status, item.CompletedTime = 0, 0
+			} else {
+				item.Status = 1
+			}
+			return m, updateTask(m.client, t, "Updated "+quote(item.Title))
+		}
+		return m, completeTask(m.client, t)
+	case "e":
+		m.mode, m.editing = modeInput, t.ID
+		m.input.Placeholder = "Title"
+		m.input.SetValue(t.Title)
+		m.input.CursorEnd()
+		return m, m.input.Focus()
+	case "p":
+		t.Priority = nextPriority(t.Priority)
+		return m, updateTask(m.client, t, fmt.Sprintf("Priority of %s set to %s", quote(t.Title), t.Priority.Name()))
+	case "m":
+		if len(m.projects) < 2 {
+			return m, nil
+		}
+		m.mode, m.moveIdx = modeMove, m.projectIdx
+		return m, nil
+	case "d":
+		m.mode = modeConfirm
+		m.confirm = fmt.Sprintf("Delete %s? (y/n)", quote(t.Title))
+		m.onYes = deleteTask(m.client, t)
+		return m, nil
+	}
+	return m, nil
+}
+
+func (m Model) moveCursor(delta int) (tea.Model, tea.Cmd) {
+	switch m.focus {
+	case focusProjects:
+		idx := clamp(m.projectIdx+delta, len(m.projects))
+		if idx == m.projectIdx {
+			return m, nil
+		}
+		m.projectIdx, m.taskIdx, m.itemIdx = idx, 0, 0
+		m.data, m.loading = nil, true
+		return m, loadData(m.client, m.projects[idx].ID)
+	case focusTasks:
+		m.taskIdx = clamp(m.taskIdx+delta, len(m.tasks()))
+		m.itemIdx = 0
+	case focusItems:
+		if t, ok := m.task(); ok {
+			m.itemIdx = clamp(m.itemIdx+delta, len(t.Items))
+		}
+	}
+	return m, nil
+}
+
+func (m Model) updateInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.mode = modeNormal
+		m.input.Blur()
+		return m, nil
+	case "enter":
+		m.mode = modeNormal
+		m.input.Blur()
+		title := strings.TrimSpace(m.input.Value())
+		if title == "" {
+			return m, nil
+		}
+		if m.editing == "" {
+			p, _ := m.project()
+			return m, createTask(m.client, p.ID, title)
+		}
+		t, ok := m.task()
+		if !ok || t.ID != m.editing || t.Title == title {
+			return m, nil
+		}
+		t.Title = title
+		return m, updateTask(m.client, t, "Renamed to "+quote(title))
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m Model) updateConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.mode = modeNormal
+	if msg.String() == "y" || msg.String() == "Y" {
+		return m, m.onYes
+	}
+	m.status = "Cancelled"
+	return m, nil
+}
+
+func (m Model) updateMove(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		m.mode = modeNormal
+	case "j", "down":
+		m.moveIdx = clamp(m.moveIdx+1, len(m.projects))
+	case "k", "up":
+		m.moveIdx = clamp(m.moveIdx-1, len(m.projects))
+	case "enter":
+		m.mode = modeNormal
+		t, ok := m.task()
+		if !ok || m.moveIdx == m.projectIdx {
+			return m, nil
+		}
+		return m, moveTask(m.client, t, m.projects[m.moveIdx])
+	}
+	return m, nil
+}
+
+func (m *Model) clampItem() {
+	t, ok := m.task()
+	if !ok {
+		m.itemIdx = 0
+		if m.focus == focusItems {
+			m.focus = focusTasks
+		}
+		return
+	}
+	m.itemIdx = clamp(m.itemIdx, len(t.Items))
+	if len(t.Items) == 0 && m.focus == focusItems {
+		m.focus = focusTasks
+	}
+}
+
+// clamp keeps i within [0, n)
+func clamp(i, n int) int {
+	return max(min(i, n-1), 0)
+}
@@ -0,0 +1,247 @@
+package tui
+
+import (
+	"fmt"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sho0pi/tickli/internal/export"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/types/task"
+	"strings"
+	"time"
+)
+
+const sidebarWidth = 24
+
+var (
+	paneStyle    = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("240")).Padding(0, 1)
+	focusedStyle = paneStyle.BorderForeground(lipgloss.Color("#3694FE"))
+	titleStyle   = lipgloss.NewStyle().Bold(true)
+	dimStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	cursorStyle  = lipgloss.NewStyle().Reverse(true)
+	errorStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#D52B24"))
+	overdueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#D52B24"))
+)
+
+const help = "↑↓ move · tab pane · n new · e edit · x complete · p priority · m move · d delete · r refresh · q quit"
+
+func (m Model) View() string {
+	if m.width == 0 {
+		return "Loading…"
+	}
+	bodyHeight := max(m.height-4, 3)
+	tasksWidth := max((m.width-sidebarWidth)/2, 20)
+	detailWidth := max(m.width-sidebarWidth-tasksWidth, 20)
+
+	body := lipgloss.JoinHorizontal(lipgloss.Top,
+		m.pane(m.viewProjects(sidebarWidth-4, bodyHeight), sidebarWidth, bodyHeight, m.focus == focusProjects || m.mode == modeMove),
+		m.pane(m.viewTasks(tasksWidth-4, bodyHeight), tasksWidth, bodyHeight, m.focus == focusTasks),
+		m.pane(m.viewDetail(detailWidth-4, bodyHeight), detailWidth, bodyHeight, m.focus == focusItems),
+	)
+	return lipgloss.JoinVertical(lipgloss.Left, body, m.viewFooter())
+}
+
+func (m Model) pane(content string, width, height int, focused bool) string {
+	style := paneStyle
+	if focused {
+		style = focusedStyle
+	}
+	return style.Width(width - 2).Height(height).MaxHeight(height + 2).Render(content)
+}
+
+func (m Model) viewProjects(width, height int) string {
+	if len(m.projects) == 0 {
+		return dimStyle.Render("No projects")
+	}
+	cursor := m.projectIdx
+	if m.mode == modeMove {
+		cursor = m.moveIdx
+	}
+	var lines []string
+	start, end := window(len(m.projects), cursor, height)
+	for i := start; i < end; i++ {
+		p := m.projects[i]
+		swatch := lipgloss.NewStyle().Foreground(lipgloss.Color(p.Color.String())).Render("■")
+		name := truncate(p.Name, width-2)
+		switch {
+		case i == cursor && (m.focus == focusProjects || m.mode == modeMove):
+			name = cursorStyle.Render(name)
+		case i == m.projectIdx:
+			name = titleStyle.Render(name)
+		}
+		lines = append(lines, swatch+" "+name)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m Model) viewTasks(width, height int) string {
+	tasks := m.tasks()
+	switch {
+	case m.loading && m.data == nil:
+		return dimStyle.Render("Loading…")
+	case len(tasks) == 0:
+		return dimStyle.Render("No tasks, press n to add one")
+	}
+	var lines []string
+	start, end := window(len(tasks), m.taskIdx, height)
+	for i := start; i < end; i++ {
+		t := tasks[i]
+		due := shortDate(t)
+		title := truncate(t.Title, width-4-lipgloss.Width(due))
+		line := priorityFlag(t.Priority) + " " + title
+		if due != "" {
+			gap := max(width-lipgloss.Width(line)-lipgloss.Width(due), 1)
+			style := dimStyle
+			if isOverdue(t) {
+				style = overdueStyle
+			}
+			line += strings.Repeat(" ", gap) + style.Render(due)
+		}
+		if i == m.taskIdx && m.focus == focusTasks {
+			line = cursorStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m Model) viewDetail(width, height int) string {
+	t, ok := m.task()
+	if !ok {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render(lipgloss.NewStyle().Width(width).Render(t.Title)) + "\n\n")
+	field := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(&sb, "%s %s\n", dimStyle.Render(fmt.Sprintf("%-9s", name)), value)
+		}
+	}
+	field("Priority", priorityFlag(t.Priority)+" "+t.Priority.Name())
+	field("Start", longDate(t, t.StartDate))
+	field("Due", longDate(t, t.DueDate))
+	if len(t.Tags) > 0 {
+		field("Tags", "#"+strings.Join(t.Tags, " #"))
+	}
+	if t.RepeatFlag != "" {
+		field("Repeat", strings.TrimPrefix(t.RepeatFlag, "RRULE:"))
+	}
+
+	for _, text := range []string{t.Desc, t.Content} {
+		if text = strings.TrimSpace(text); text != "" {
+			sb.WriteString("\n" + lipgloss.NewStyle().Width(width).Render(text) + "\n")
+		}
+	}
+
+	if len(t.Items) > 0 {
+		done := 0
+		for _, item := range t.Items {
+			if item.IsCompleted() {
+				done++
+			}
+		}
+		sb.WriteString("\n" + dimStyle.Render(fmt.Sprintf("Checklist %d/%d", done, len(t.Items))) + "\n")
+		for i, item := range t.Items {
+			box := "[ ]"
+			if item.IsCompleted() {
+				box = "[x]"
+			}
+			line := box + " " + truncate(item.Title, width-4)
+			if i == m.itemIdx && m.focus == focusItems {
+				line = cursorStyle.Render(line)
+			} else if item.IsCompleted() {
+				line = dimStyle.Render(line)
+			}
+			sb.WriteString(line + "\n")
+		}
+	}
+
+	// Keep the selected checklist item visible in long details
+	lines := strings.Split(strings.TrimRight(sb.String(), "\n"), "\n")
+	if len(lines) > height && m.focus == focusItems {
+		offset := len(lines) - len(t.Items) + m.itemIdx - height + 1
+		if offset > 0 {
+			lines = lines[offset:]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m Model) viewFooter() string {
+	switch m.mode {
+	case modeInput:
+		return " " + m.input.View()
+	case modeConfirm:
+		return " " + titleStyle.Render(m.confirm)
+	case modeMove:
+		return " Move to which project? ↑↓ select · enter move · esc cancel"
+	}
+	width := m.width - 2
+	switch {
+	case m.err != nil:
+		return " " + errorStyle.Render(truncate("Error: "+m.err.Error(), width))
+	case m.status != "":
+		status := truncate(m.status, width)
+		return " " + status + dimStyle.Render(truncate(" · "+help, width-lipgloss.Width(status)))
+	}
+	return " " + dimStyle.Render(truncate(help, width))
+}
+
+func priorityFlag(p task.Priority) string {
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("#" + p.Color().RGB().Hex())).Render("⚑")
+}
+
+func shortDate(t types.Task) string {
+	if t.DueDate.IsZero() {
+		return ""
+	}
+	due := export.LocalTime(t.DueDate, t.TimeZone)
+	if t.IsAllDay {
+		return due.Format("Jan 2")
+	}
+	return due.Format("Jan 2 15:04")
+}
+
+func longDate(t types.Task, ts types.TickTickTime) string {
+	if ts.IsZero() {
+		return ""
+	}
+	local := export.LocalTime(ts, t.TimeZone)
+	if t.IsAllDay {
+		return local.Format("Mon Jan 2 2006")
+	}
+	return local.Format("Mon Jan 2 2006 15:04 MST")
+}
+
+func isOverdue(t types.Task) bool {
+	if t.DueDate.IsZero() || t.Status == task.StatusComplete {
+		return false
+	}
+	due := export.LocalTime(t.DueDate, t.TimeZone)
+	if t.IsAllDay {
+		due = due.AddDate(0, 0, 1)
+	}
+	return due.Before(time.Now())
+}
+
+// window returns the range of n rows to show in height lines, keeping the cursor visible
+func window(n, cursor, height int) (int, int) {
+	if n <= height {
+		return 0, n
+	}
+	start := max(min(cursor-height/2, n-height), 0)
+	return start, start + height
+}
+
+func truncate(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+1 > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
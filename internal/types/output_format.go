@@ -3,32 +3,65 @@ package types
 import (
 	"fmt"
 	"github.com/spf13/cobra"
+	"strings"
 )
 
 type OutputFormat string
 
 const (
-	OutputSimple OutputFormat = "simple"
-	OutputJSON   OutputFormat = "json"
+	OutputSimple   OutputFormat = "simple"
+	OutputJSON     OutputFormat = "json"
+	OutputTemplate OutputFormat = "template"
+	OutputTable    OutputFormat = "table"
+	OutputYAML     OutputFormat = "yaml"
+	OutputCSV      OutputFormat = "csv"
+	OutputTSV      OutputFormat = "tsv"
+	OutputNDJSON   OutputFormat = "ndjson"
 )
 
 var OutputFormatCompletion = []cobra.Completion{
 	cobra.CompletionWithDesc("simple", "Simple output format"),
 	cobra.CompletionWithDesc("json", "JSON output format"),
+	cobra.CompletionWithDesc("table", "Aligned table output format"),
+	cobra.CompletionWithDesc("yaml", "YAML output format"),
+	cobra.CompletionWithDesc("csv", "Comma separated values with a header"),
+	cobra.CompletionWithDesc("tsv", "Tab separated values with a header"),
+	cobra.CompletionWithDesc("ndjson", "Newline delimited JSON for streaming"),
+	cobra.CompletionWithDesc("template=", "Go template output format (e.g., 'template={{.Title}}')"),
 }
 
 var OutputFormatCompletionFunc = cobra.FixedCompletions(OutputFormatCompletion, cobra.ShellCompDirectiveNoFileComp)
 
+// SimpleOrJSONCompletionFunc completes the output of commands printing only simple or json
+var SimpleOrJSONCompletionFunc = cobra.FixedCompletions(OutputFormatCompletion[:2], cobra.ShellCompDirectiveNoFileComp)
+
 func (o *OutputFormat) Set(value string) error {
-	switch OutputFormat(value) {
-	case OutputSimple, OutputJSON:
-		*o = OutputFormat(value)
+	format := OutputFormat(value)
+	switch format.Kind() {
+	case OutputSimple, OutputJSON, OutputTable, OutputYAML, OutputCSV, OutputTSV, OutputNDJSON:
+		if format.Arg() != "" {
+			return fmt.Errorf("output format %s does not take an argument", format.Kind())
+		}
+	case OutputTemplate:
 	default:
 		return fmt.Errorf("invalid output format: %s", value)
 	}
+	*o = format
 	return nil
 }
 
+// Kind returns the format without its argument, e.g. "template" for "template={{.Title}}"
+func (o OutputFormat) Kind() OutputFormat {
+	kind, _, _ := strings.Cut(string(o), "=")
+	return OutputFormat(kind)
+}
+
+// Arg returns the argument of a parameterised format such as template=<go-template>
+func (o OutputFormat) Arg() string {
+	_, arg, _ := strings.Cut(string(o), "=")
+	return arg
+}
+
 func (o OutputFormat) String() string {
 	return string(o)
 }
@@ -1,6 +1,9 @@
 package types
 
-import "github.com/sho0pi/tickli/internal/types/project"
+import (
+	"github.com/sho0pi/tickli/internal/types/project"
+	"strings"
+)
 
 // InboxProject the Inbox project representation (cause is not returned by the api)
 var InboxProject = Project{
@@ -39,3 +42,18 @@ type Column struct {
 	Name      string `json:"name"`
 	SortOrder int64  `json:"sortOrder"`
 }
+
+// FindColumn finds a column by ID, then by case-insensitive name
+func FindColumn(columns []Column, nameOrID string) (Column, bool) {
+	for _, c := range columns {
+		if c.ID == nameOrID {
+			return c, true
+		}
+	}
+	for _, c := range columns {
+		if strings.EqualFold(strings.TrimSpace(c.Name), strings.TrimSpace(nameOrID)) {
+			return c, true
+		}
+	}
+	return Column{}, false
+}
@@ -0,0 +1,48 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var durationPattern = regexp.MustCompile(`^P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// ReminderTrigger returns the iCalendar duration of a TickTick reminder, e.g. "-PT15M" for "TRIGGER:-PT15M"
+func ReminderTrigger(reminder string) string {
+	return strings.TrimPrefix(strings.TrimSpace(reminder), "TRIGGER:")
+}
+
+// ReminderOffset parses a TickTick reminder into its offset from the task time,
+// which is the start of the day for all-day tasks
+func ReminderOffset(reminder string) (time.Duration, error) {
+	trigger := ReminderTrigger(reminder)
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(trigger, "-"):
+		sign = -1
+		trigger = trigger[1:]
+	case strings.HasPrefix(trigger, "+"):
+		trigger = trigger[1:]
+	}
+
+	m := durationPattern.FindStringSubmatch(trigger)
+	if m == nil || trigger == "P" || trigger == "PT" {
+		return 0, fmt.Errorf("invalid reminder trigger: %s", reminder)
+	}
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var offset time.Duration
+	for i, unit := range units {
+		if m[i+1] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[i+1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid reminder trigger: %s", reminder)
+		}
+		offset += time.Duration(n) * unit
+	}
+	return sign * offset, nil
+}
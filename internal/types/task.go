@@ -5,6 +5,7 @@ import "github.com/sho0pi/tickli/internal/types/task"
 type Task struct {
 	ID            string          `json:"id"`
 	ProjectID     string          `json:"projectId"`
+	ColumnID      string          `json:"columnId,omitempty"`
 	Title         string          `json:"title"`
 	IsAllDay      bool            `json:"isAllDay"`
 	CompletedTime TickTickTime    `json:"completedTime,omitzero"`
@@ -29,6 +30,11 @@ type ChecklistItem struct {
 	CompletedTime int64        `json:"completedTime"`
 	IsAllDay      bool         `json:"isAllDay"`
 	SortOrder     int64        `json:"sortOrder"`
-	StartDate     TickTickTime `json:"startDate"`
+	StartDate     TickTickTime `json:"startDate,omitzero"`
 	TimeZone      string       `json:"timeZone"`
 }
+
+// IsCompleted reports whether the checklist item is checked, items use 1 rather than task.StatusComplete
+func (i ChecklistItem) IsCompleted() bool {
+	return i.Status != 0
+}
@@ -59,19 +59,31 @@ func (p Priority) MarshalJSON() ([]byte, error) {
 }
 
 func (p Priority) String() string {
-	flag := "⚑"
+	return p.Color().Sprint("⚑")
+}
+
+// Name returns the priority level as accepted by Set (none, low, medium or high)
+func (p Priority) Name() string {
+	for name, priority := range priorityMap {
+		if priority == p {
+			return name
+		}
+	}
+	return "none"
+}
+
+// Color returns the color TickTick uses to flag this priority
+func (p Priority) Color() color.Color256 {
 	switch p {
-	case PriorityNone:
-		flag = NonePriorityColor.Sprint(flag)
 	case PriorityLow:
-		flag = LowPriorityColor.Sprint(flag)
+		return LowPriorityColor
 	case PriorityMedium:
-		flag = MediumPriorityColor.Sprint(flag)
+		return MediumPriorityColor
 	case PriorityHigh:
-		flag = HighPriorityColor.Sprint(flag)
+		return HighPriorityColor
+	default:
+		return NonePriorityColor
 	}
-
-	return flag
 }
 
 func (p *Priority) Set(value string) error {
@@ -40,3 +40,13 @@ func (s Status) String() string {
 		return color.Red.Sprint("☒")
 	}
 }
+
+// Name returns a plain text representation of the status
+func (s Status) Name() string {
+	switch s {
+	case StatusComplete:
+		return "completed"
+	default:
+		return "normal"
+	}
+}
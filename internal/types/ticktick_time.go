@@ -35,3 +35,12 @@ func (t TickTickTime) String() string {
 func (t TickTickTime) Humanize() string {
 	return humanize.Time(time.Time(t))
 }
+
+func (t TickTickTime) IsZero() bool {
+	return time.Time(t).IsZero()
+}
+
+// Equal reports whether both times are the same instant
+func (t TickTickTime) Equal(u TickTickTime) bool {
+	return time.Time(t).Equal(time.Time(u))
+}
@@ -3,13 +3,44 @@ package utils
 import (
 	"github.com/rs/zerolog/log"
 	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/cache"
 	"github.com/sho0pi/tickli/internal/config"
+	"github.com/sho0pi/tickli/internal/history"
+	"github.com/sho0pi/tickli/internal/trash"
+	"time"
 )
 
+// CacheOptions are set by the global --cache-ttl, --refresh and --offline flags
+var CacheOptions = api.CacheOptions{
+	TTL: 5 * time.Minute,
+}
+
 func LoadClient() api.Client {
 	token, err := config.LoadToken()
 	if err != nil {
 		log.Fatal().Err(err).Msg("Please run 'tickli init' first")
 	}
-	return *api.NewClient(token)
+	return *NewClient(token)
+}
+
+// NewClient returns a client reading through the on-disk cache, recording its changes in the history
+// and keeping what it deletes in the trash
+func NewClient(token string) *api.Client {
+	client := api.NewClient(token)
+	client.UseCache(cache.New(config.CacheDir()), CacheOptions)
+	client.UseHistory(history.Open(config.HistoryPath()))
+	client.UseTrash(OpenTrash())
+	return client
+}
+
+// OpenTrash returns the local trash with the retention of the config
+func OpenTrash() *trash.Trash {
+	retention := trash.DefaultRetention
+	cfg, err := config.Load()
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to load config, using the default trash retention")
+	} else if cfg.Trash != nil {
+		retention = time.Duration(cfg.Trash.RetentionDays) * 24 * time.Hour
+	}
+	return trash.Open(config.TrashDir(), retention)
 }
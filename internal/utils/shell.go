@@ -0,0 +1,15 @@
+package utils
+
+import (
+	"context"
+	"os/exec"
+	"runtime"
+)
+
+// ShellCommand returns a command running the user's shell command line, through cmd on Windows
+func ShellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
@@ -2,9 +2,11 @@ package utils
 
 import (
 	"fmt"
+	"github.com/gookit/color"
 	"github.com/ktr0731/go-fuzzyfinder"
 	"github.com/sho0pi/tickli/internal/types"
 	"github.com/sho0pi/tickli/internal/types/project"
+	"strings"
 )
 
 func GetProjectDescription(project types.Project) string {
@@ -73,6 +75,19 @@ Tasks:`,
 	return description
 }
 
+// GetTaskSummary returns a compact line for the task: its priority flag, title and tags, followed by
+// its project in the project colour
+func GetTaskSummary(task types.Task, p types.Project) string {
+	summary := task.Priority.Color().Sprint("⚑") + " " + task.Title
+	if len(task.Tags) > 0 {
+		summary += color.Cyan.Sprint(" #" + strings.Join(task.Tags, " #"))
+	}
+	if p.Name != "" {
+		summary += "  " + p.Color.Sprint(p.Name)
+	}
+	return summary
+}
+
 func FuzzySelectProject(projects []types.Project, query string) (types.Project, error) {
 	if len(projects) == 0 {
 		return types.Project{}, fmt.Errorf("no projects available for selection")
@@ -0,0 +1,38 @@
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/utils"
+	"io"
+	"os"
+	"strings"
+)
+
+// RunHook runs a shell command for the event. The event is written as JSON on its stdin,
+// and its main fields are set in TICKLI_* environment variables.
+func RunHook(ctx context.Context, command string, e Event, stdout, stderr io.Writer) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, "encoding event")
+	}
+
+	cmd := utils.ShellCommand(ctx, command)
+	cmd.Stdin = strings.NewReader(string(data) + "\n")
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	cmd.Env = append(os.Environ(),
+		"TICKLI_EVENT="+string(e.Type),
+		"TICKLI_PROJECT_ID="+e.ProjectID,
+		"TICKLI_PROJECT="+e.Project,
+		"TICKLI_TASK_ID="+e.Task.ID,
+		"TICKLI_TASK_TITLE="+e.Task.Title,
+		fmt.Sprintf("TICKLI_TASK_PRIORITY=%d", e.Task.Priority),
+		"TICKLI_TASK_TAGS="+strings.Join(e.Task.Tags, ","),
+	)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "hook %q", command)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package watch
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/sho0pi/tickli/internal/api"
+	"github.com/sho0pi/tickli/internal/backup"
+	"github.com/sho0pi/tickli/internal/types"
+	"github.com/sho0pi/tickli/internal/types/task"
+	"github.com/spf13/cobra"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventCreated   EventType = "created"
+	EventCompleted EventType = "completed"
+	EventUpdated   EventType = "updated"
+	EventDeleted   EventType = "deleted"
+)
+
+var EventTypeCompletion = []cobra.Completion{
+	cobra.CompletionWithDesc(string(EventCreated), "A task was added"),
+	cobra.CompletionWithDesc(string(EventCompleted), "A task was completed"),
+	cobra.CompletionWithDesc(string(EventUpdated), "Fields of a task changed"),
+	cobra.CompletionWithDesc(string(EventDeleted), "A task was deleted"),
+}
+
+var EventTypeCompletionFunc = cobra.FixedCompletions(EventTypeCompletion, cobra.ShellCompDirectiveNoFileComp)
+
+// Event is a task change seen between two polls
+type Event struct {
+	Type      EventType `json:"type"`
+	Time      time.Time `json:"time"`
+	ProjectID string    `json:"projectId"`
+	Project   string    `json:"project"`
+	// Task is the current task, or its last known state for deleted tasks
+	Task types.Task `json:"task"`
+	// Fields lists the changed fields of updated tasks
+	Fields []backup.FieldChange `json:"fields,omitempty"`
+}
+
+// Watcher polls projects, diffing them with the state of the previous poll
+type Watcher struct {
+	Client *api.Client
+	// ProjectIDs are the IDs of the watched projects, every open project when empty
+	ProjectIDs []string
+	// StatePath keeps the last state between runs, so changes made while not watching are reported
+	StatePath string
+
+	last *backup.Archive
+}
+
+// StatePath returns where the last state of watching the projects is kept, in dir
+func StatePath(dir string, projectIDs []string) string {
+	ids := slices.Clone(projectIDs)
+	slices.Sort(ids)
+	sum := sha256.Sum256([]byte(strings.Join(ids, "\n")))
+	return filepath.Join(dir, "watch", fmt.Sprintf("%x.json", sum[:8]))
+}
+
+// Poll fetches the projects and returns the task events since the previous poll.
+// The first poll without a saved state only records the state.
+func (w *Watcher) Poll() ([]Event, error) {
+	if w.last == nil && w.StatePath != "" {
+		if _, err := os.Stat(w.StatePath); err == nil {
+			if w.last, err = backup.ReadFile(w.StatePath); err != nil {
+				return nil, errors.Wrap(err, "failed to read watch state")
+			}
+		}
+	}
+
+	data, err := w.Client.WithRefresh().ListProjectsData(w.ProjectIDs...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch projects")
+	}
+	current := &backup.Archive{Version: backup.Version, CreatedAt: time.Now().UTC(), Projects: data}
+
+	var events []Event
+	if w.last != nil {
+		events = Diff(w.Client, w.last, current)
+	}
+	w.last = current
+	if w.StatePath != "" {
+		if err := os.MkdirAll(filepath.Dir(w.StatePath), 0700); err != nil {
+			return events, errors.Wrap(err, "creating watch state directory")
+		}
+		if err := backup.WriteFile(w.StatePath, current); err != nil {
+			return events, errors.Wrap(err, "failed to save watch state")
+		}
+	}
+	return events, nil
+}
+
+// Diff returns the task events from snapshot a to snapshot b, asking the server whether
+// vanished tasks were completed or deleted
+func Diff(client *api.Client, a, b *backup.Archive) []Event {
+	diff := backup.Compare(a, b)
+	diff.ResolveCompleted(client)
+
+	before, after := indexTasks(a), indexTasks(b)
+	var events []Event
+	for _, c := range diff.Changes {
+		if c.Type != "task" {
+			continue
+		}
+		t, ok := after[c.ID]
+		if !ok {
+			t = before[c.ID]
+		}
+		e := Event{Time: b.CreatedAt, ProjectID: t.ProjectID, Project: c.Project, Task: t}
+		switch c.Kind {
+		case backup.ChangeAdded:
+			e.Type = EventCreated
+		case backup.ChangeCompleted:
+			e.Type = EventCompleted
+			e.Task.Status = task.StatusComplete
+		case backup.ChangeModified:
+			e.Type, e.Fields = EventUpdated, c.Fields
+		case backup.ChangeRemoved:
+			e.Type = EventDeleted
+		}
+		events = append(events, e)
+	}
+	return events
+}
+
+func indexTasks(a *backup.Archive) map[string]types.Task {
+	index := make(map[string]types.Task)
+	for _, p := range a.Projects {
+		for _, t := range p.Tasks {
+			index[t.ID] = t
+		}
+	}
+	return index
+}